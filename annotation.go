@@ -0,0 +1,101 @@
+package ipam
+
+import "fmt"
+
+// DumpOptions decides what Dump includes
+type DumpOptions struct {
+	// Include the buckets of zones, see Dump
+	Fat bool
+	// Omit the annotations of zones and reserved addrs from thin dumps, they are lost when loaded
+	OmitAnnotations bool
+}
+
+// withoutAnnotations return a copy of descs whose descriptors have no annotations
+func withoutAnnotations(descs map[string]*Descriptor) map[string]*Descriptor {
+	if descs == nil {
+		return nil
+	}
+	result := make(map[string]*Descriptor, len(descs))
+	for addr, desc := range descs {
+		if desc == nil || len(desc.Annotations) <= 0 {
+			result[addr] = desc
+			continue
+		}
+		stripped := *desc
+		stripped.Annotations = nil
+		result[addr] = &stripped
+	}
+	return result
+}
+
+func (i *ipam) SetZoneAnnotation(literal, key, value string) error {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
+	}
+	if zone.storage.Annotations == nil {
+		zone.storage.Annotations = make(map[string]string)
+	}
+	zone.storage.Annotations[key] = value
+	return nil
+}
+
+func (i *ipam) RemoveZoneAnnotation(literal, key string) (string, bool) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return "", false
+	}
+	value, ok := zone.storage.Annotations[key]
+	delete(zone.storage.Annotations, key)
+	return value, ok
+}
+
+func (i *ipam) ZoneAnnotations(literal string) (LabelMap, bool) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, false
+	}
+	return LabelMap(zone.storage.Annotations).Copy(), true
+}
+
+func (i *ipam) SetAddrAnnotation(specific, key, value string) error {
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return err
+	}
+	ok := zone.Update(ip, func(desc *Descriptor) {
+		if desc.Annotations == nil {
+			desc.Annotations = make(map[string]string)
+		}
+		desc.Annotations[key] = value
+	})
+	if !ok {
+		return fmt.Errorf("IP %s not allocated or reserved", specific)
+	}
+	return nil
+}
+
+func (i *ipam) RemoveAddrAnnotation(specific, key string) error {
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return err
+	}
+	ok := zone.Update(ip, func(desc *Descriptor) {
+		delete(desc.Annotations, key)
+	})
+	if !ok {
+		return fmt.Errorf("IP %s not allocated or reserved", specific)
+	}
+	return nil
+}
+
+func (i *ipam) AddrAnnotations(specific string) (LabelMap, error) {
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return nil, err
+	}
+	if desc, ok := zone.Describe(ip); ok {
+		return LabelMap(desc.Annotations).Copy(), nil
+	}
+	return nil, fmt.Errorf("IP %s not allocated or reserved", specific)
+}
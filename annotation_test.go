@@ -0,0 +1,68 @@
+package ipam
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	ipam1 := New("test", nil)
+	ipam1.AddZone("10.0.0.0/24", false)
+	blob := `{"note": "` + strings.Repeat("x", 1024) + `"}`
+	if err := ipam1.SetZoneAnnotation("10.0.0.0/24", "provision", blob); err != nil {
+		t.Fatal(err)
+	}
+	ipam1.AllocAddrSpecific("10.0.0.1", map[string]string{"app": "web"})
+	ipam1.ReserveAddr("10.0.0.2", nil)
+	if err := ipam1.SetAddrAnnotation("10.0.0.1", "provision", blob); err != nil {
+		t.Fatal(err)
+	}
+	ipam1.SetAddrAnnotation("10.0.0.1", "note", "x")
+	ipam1.SetAddrAnnotation("10.0.0.2", "note", "y")
+	if err := ipam1.SetAddrAnnotation("10.0.0.3", "note", "z"); err == nil {
+		t.Fatal("Free addr should not be annotated")
+	}
+	if err := ipam1.RemoveAddrAnnotation("10.0.0.1", "note"); err != nil {
+		t.Fatal(err)
+	}
+	annotations, _ := ipam1.AddrAnnotations("10.0.0.1")
+	if len(annotations) != 1 || annotations["provision"] != blob {
+		t.Fatalf("Wrong annotations %v", annotations)
+	}
+	if labels, _ := ipam1.AddrLabels("10.0.0.1"); len(labels) != 1 {
+		t.Fatalf("Annotations should not be labels, got %v", labels)
+	}
+	if entries, _ := ipam1.FindAddrs("provision", ""); len(entries) != 0 {
+		t.Fatal("Annotations should not be queried")
+	}
+	if entries, _ := ipam1.FindAddrs("app=web", ""); entries[0].Descriptor.Annotations["provision"] != blob {
+		t.Fatal("Entries should have annotations")
+	}
+
+	raw, _ := ipam1.Dump(false)
+	stripped, _ := ipam1.DumpWithOptions(&DumpOptions{OmitAnnotations: true})
+	if thin, err := ipam1.DumpWithOptions(nil); err != nil || string(thin) != string(raw) {
+		t.Fatal("Nil options should dump the same as Dump(false)")
+	}
+	if len(stripped) >= len(raw) || strings.Contains(string(stripped), "note") {
+		t.Fatal("Annotations should be omitted from thin dump")
+	}
+	ipam2 := New("test", nil)
+	ipam2.Load(raw)
+	if annotations, ok := ipam2.ZoneAnnotations("10.0.0.0/24"); !ok || annotations["provision"] != blob {
+		t.Fatal("Zone annotations should be loaded")
+	}
+	if annotations, _ := ipam2.AddrAnnotations("10.0.0.2"); annotations["note"] != "y" {
+		t.Fatal("Annotations of reserved addrs should be loaded")
+	}
+	ipam2.ClaimReserved("10.0.0.2", nil)
+	if annotations, _ := ipam2.AddrAnnotations("10.0.0.2"); annotations["note"] != "y" {
+		t.Fatal("Annotations should be kept by claim")
+	}
+	if value, ok := ipam2.RemoveZoneAnnotation("10.0.0.0/24", "provision"); !ok || value != blob {
+		t.Fatal("Zone annotation should be removed")
+	}
+	if annotations, _ := ipam1.AddrAnnotations("10.0.0.2"); annotations["note"] != "y" {
+		t.Fatal("Dump should not strip annotations in memory")
+	}
+}
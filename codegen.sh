@@ -1,3 +1,3 @@
 #!/bin/sh
 
-protoc -I=. --gofast_out=paths=source_relative,plugins=grpc:. storage.proto
+protoc -I=. -I="$(go env GOMODCACHE)/github.com/gogo/protobuf@v1.3.2" --gofast_out=paths=source_relative,plugins=grpc:. storage.proto
@@ -5,36 +5,144 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"sort"
 	"strings"
 )
 
 var one = big.NewInt(1)
 
-type ipam struct {
+// instance is shared by all namespaces of an IPAM
+type instance struct {
 	// mutex  sync.RWMutex
-	prefix string
-	zones  map[string]*zone
-	labels LabelMap
+	prefix   string
+	labels   LabelMap
+	loader   BucketLoader
+	strategy AllocStrategy
+	// Map key is the namespace name, value is zones of the namespace indexed by literal
+	namespaces map[string]map[string]*zone
+	// Map key is the namespace name, value is quotas of the namespace indexed by name
+	quotas map[string]map[string]*Quota
+	// Map key is the namespace name, value is unique labels defined in the namespace indexed by key
+	uniques map[string]map[string]*UniqueLabel
+	// Map key is the namespace name, value is the index of zone labels to literals
+	zoneIndexes map[string]labelIndex
+	// Policy validating labels of IPAM, zones and addrs, nil means all labels are accepted
+	policy LabelPolicy
+	// Default thresholds of zones, sorted
+	thresholds       []float64
+	thresholdHandler ThresholdHandler
+}
+
+// ipam is the view of an instance scoped to one namespace
+type ipam struct {
+	*instance
+	namespace string
+	zones     map[string]*zone
 }
 
 func New(prefix string, labels LabelMap) IPAM {
-	ipam := &ipam{prefix: prefix, zones: make(map[string]*zone)}
+	ins := &instance{
+		prefix:      prefix,
+		namespaces:  make(map[string]map[string]*zone),
+		quotas:      make(map[string]map[string]*Quota),
+		uniques:     make(map[string]map[string]*UniqueLabel),
+		zoneIndexes: make(map[string]labelIndex),
+	}
 	if labels != nil {
-		ipam.labels = labels.Copy()
+		ins.labels = labels.Copy()
 	} else {
-		ipam.labels = make(LabelMap)
+		ins.labels = make(LabelMap)
+	}
+	return ins.view(DefaultNamespace)
+}
+
+// NewWithPolicy is same as New, but labels of IPAM, zones and addrs are validated by policy
+func NewWithPolicy(prefix string, labels LabelMap, policy LabelPolicy) (IPAM, error) {
+	i := New(prefix, nil).(*ipam)
+	i.policy = policy
+	if labels == nil {
+		labels = make(LabelMap)
+	}
+	if err := i.validateLabels(nil, TargetIPAM, nil, labels); err != nil {
+		return nil, err
+	}
+	i.labels = labels.Copy()
+	return i, nil
+}
+
+// namespaceZones return the zones of namespace, create it if not exists
+func (ins *instance) namespaceZones(namespace string) map[string]*zone {
+	zones, ok := ins.namespaces[namespace]
+	if !ok {
+		zones = make(map[string]*zone)
+		ins.namespaces[namespace] = zones
+	}
+	return zones
+}
+
+func (ins *instance) view(namespace string) *ipam {
+	return &ipam{instance: ins, namespace: namespace, zones: ins.namespaceZones(namespace)}
+}
+
+// sortedNamespaces return names of all namespaces in order
+func (ins *instance) sortedNamespaces() []string {
+	result := make([]string, 0, len(ins.namespaces))
+	for name := range ins.namespaces {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// allZones return zones of all namespaces, ordered by namespace and address
+func (ins *instance) allZones() []*zone {
+	result := make([]*zone, 0)
+	for _, name := range ins.sortedNamespaces() {
+		result = append(result, sortedZones(ins.namespaces[name])...)
+	}
+	return result
+}
+
+func (i *ipam) Namespace(name string) IPAM {
+	return i.view(name)
+}
+
+func (i *ipam) CurrentNamespace() string {
+	return i.namespace
+}
+
+func (i *ipam) Namespaces() []string {
+	results := make([]string, 0)
+	for _, name := range i.sortedNamespaces() {
+		if len(i.namespaces[name]) > 0 {
+			results = append(results, name)
+		}
 	}
-	return ipam
+	return results
 }
 
-func (i *ipam) SetLabel(key, value string) {
+func (i *ipam) SetLabel(key, value string) error {
+	labels := i.labels.Copy()
+	labels[key] = value
+	if err := i.validateLabels(nil, TargetIPAM, i.labels, labels); err != nil {
+		return err
+	}
 	i.labels[key] = value
+	return nil
 }
 
-func (i *ipam) RemoveLabel(key string) (string, bool) {
+func (i *ipam) RemoveLabel(key string) (string, bool, error) {
 	value, ok := i.labels[key]
+	if !ok {
+		return "", false, nil
+	}
+	labels := i.labels.Copy()
+	delete(labels, key)
+	if err := i.validateLabels(nil, TargetIPAM, i.labels, labels); err != nil {
+		return "", false, err
+	}
 	delete(i.labels, key)
-	return value, ok
+	return value, true, nil
 }
 
 func (i *ipam) Labels() LabelMap {
@@ -46,111 +154,82 @@ func (i *ipam) Labels() LabelMap {
 
 func (i *ipam) overlappedWith(zone *zone) bool {
 	for _, z := range i.zones {
-		if z.start.Cmp(zone.end) > 0 || z.end.Cmp(zone.start) < 0 {
-			continue
+		if z.Overlapped(zone) {
+			return true
 		}
-		return true
 	}
 	return false
 }
 
-func (i *ipam) createZoneSingle(ip net.IP, lazy bool) *zone {
-	ipBigInt := IPToBigInt(ip)
-	zone := &zone{
-		start: ipBigInt,
-		end:   ipBigInt,
-		lazy:  lazy,
-		storage: &Zone{
-			Literal: ip.String(),
-			Buckets: make(map[string]*Bucket),
-			Labels:  make(map[string]string),
-		},
-	}
-	if IsIPv4(ip) {
-		zone.version = 4
-	} else {
-		zone.version = 6
-	}
-	return zone
-}
-
-func (i *ipam) createZoneCIDR(cidr *net.IPNet, lazy bool) *zone {
-	// 一些准备工作
-	zone := &zone{
-		lazy: lazy,
-		storage: &Zone{
-			Literal: cidr.String(),
-			Buckets: make(map[string]*Bucket),
-			Labels:  make(map[string]string),
-		},
-	}
-	ones, _ := cidr.Mask.Size()
-	// 计算基址和偏移
-	local := IPToBigInt(cidr.IP)
-	var offset *big.Int
-	if IsIPv4(cidr.IP) {
-		zone.version = 4
-		offset = new(big.Int).Sub(new(big.Int).Lsh(one, uint(32-ones)), one)
-	} else {
-		zone.version = 6
-		offset = new(big.Int).Sub(new(big.Int).Lsh(one, uint(128-ones)), one)
-	}
-	zone.start = new(big.Int).Add(local, one)                         // start避开0地址
-	zone.end = new(big.Int).Sub(new(big.Int).Add(local, offset), one) // end避开广播地址
-	return zone
-}
-
-func (i *ipam) createZoneRange(low, high net.IP, lazy bool) *zone {
-	start := IPToBigInt(low)
-	end := IPToBigInt(high)
-	zone := &zone{
-		start: start,
-		end:   end,
-		lazy:  lazy,
-		storage: &Zone{
-			Literal: low.String() + "-" + high.String(),
-			Buckets: make(map[string]*Bucket),
-			Labels:  make(map[string]string),
-		},
-	}
-	if IsIPv4(low) {
-		zone.version = 4
-	} else {
-		zone.version = 6
+// touch pages in the buckets of a lazy zone
+func (i *ipam) touch(zone *zone) error {
+	if !zone.lazy {
+		return nil
+	}
+	if i.loader == nil {
+		for key, bucket := range zone.storage.Buckets {
+			if bucket == nil {
+				return fmt.Errorf("Bucket %s of zone %s not loaded and no bucket loader set", key, zone.storage.Literal)
+			}
+		}
+		return nil
 	}
-	return zone
+	return zone.PageIn(i.loader)
 }
 
-func (i *ipam) AddZone(literal string, lazy bool) error {
-	if _, ok := i.zones[literal]; ok {
-		return fmt.Errorf("Zone literal %s already exitst", literal)
+// zoneOf return the zone containing ip, nil if not found
+func (i *ipam) zoneOf(ip net.IP) *zone {
+	for _, zone := range i.zones {
+		if zone.Contains(ip) {
+			return zone
+		}
 	}
+	return nil
+}
 
-	var zone *zone
-	// 根据literal的格式不同有不同的zone生成方式
-	if single := net.ParseIP(literal); single != nil {
-		zone = i.createZoneSingle(single, lazy)
-	} else if ip, ipnet, err := net.ParseCIDR(literal); err == nil {
-		if !ip.Equal(ipnet.IP) {
-			return errors.New("Invalid CIDR network value")
-		}
-		zone = i.createZoneCIDR(ipnet, lazy)
-	} else if pair := strings.Split(literal, "-"); len(pair) == 2 {
-		low := net.ParseIP(pair[0])
-		high := net.ParseIP(pair[1])
-		if low == nil || high == nil {
-			return errors.New("Invalid IP range value")
-		}
-		if (IsIPv4(low) && !IsIPv4(high)) || (IsIPv6(low) && !IsIPv6(high)) {
-			return errors.New("Invalid IP range value: IPs format are different")
-		}
-		if IPToBigInt(low).Cmp(IPToBigInt(high)) >= 0 {
-			return errors.New("The left IP should be less than the right one")
-		}
-		zone = i.createZoneRange(low, high, lazy)
-	} else {
-		return errors.New("Invalid format")
+// lookupAddr return the touched zone containing specific addr
+func (i *ipam) lookupAddr(specific string) (*zone, net.IP, error) {
+	ip := net.ParseIP(specific)
+	if ip == nil {
+		return nil, nil, fmt.Errorf("Invalid IP format %s", specific)
+	}
+	zone := i.zoneOf(ip)
+	if zone == nil {
+		return nil, nil, fmt.Errorf("IP %s is not handled", specific)
+	}
+	if err := i.touch(zone); err != nil {
+		return nil, nil, err
+	}
+	return zone, ip, nil
+}
+
+func (i *ipam) getZone(literal string) (*zone, error) {
+	lit, err := parseLiteral(literal)
+	if err != nil {
+		return nil, err
+	}
+	zone, ok := i.zones[lit.canonical]
+	if !ok {
+		return nil, fmt.Errorf("IP literal %s not exists", literal)
+	}
+	return zone, nil
+}
+
+func (i *ipam) AddZone(literal string, lazy bool) error {
+	lit, err := parseLiteral(literal)
+	if err != nil {
+		return err
 	}
+	if _, ok := i.zones[lit.canonical]; ok {
+		return fmt.Errorf("Zone literal %s already exitst", lit.canonical)
+	}
+	zone := newZone(lit, &Zone{
+		Literal:   lit.canonical,
+		Buckets:   make(map[string]*Bucket),
+		Labels:    make(map[string]string),
+		Lazy:      lazy,
+		Namespace: i.namespace,
+	}, lazy)
 	if i.overlappedWith(zone) {
 		return errors.New("Literal overlapped")
 	}
@@ -160,224 +239,468 @@ func (i *ipam) AddZone(literal string, lazy bool) error {
 }
 
 func (i *ipam) SetZoneLabel(literal, key, value string) error {
-	zone, ok := i.zones[strings.ToLower(literal)]
-	if !ok {
-		return fmt.Errorf("IP literal %s not exists", literal)
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
+	}
+	labels := LabelMap(zone.storage.Labels).Copy()
+	labels[key] = value
+	if err := i.validateLabels(zone, TargetZone, zone.storage.Labels, labels); err != nil {
+		return err
 	}
+	if zone.storage.Labels == nil {
+		zone.storage.Labels = make(map[string]string)
+	}
+	idx := i.zoneIndex(i.namespace)
+	idx.remove(zone.storage.Literal, zone.storage.Labels)
 	zone.storage.Labels[key] = value
+	idx.add(zone.storage.Literal, zone.storage.Labels)
 	return nil
 }
 
 func (i *ipam) RemoveZone(literal string) error {
-	if single := net.ParseIP(literal); single != nil {
-		goto del
-	} else if ip, ipnet, err := net.ParseCIDR(literal); err == nil {
-		if !ip.Equal(ipnet.IP) {
-			return errors.New("Invalid CIDR network value")
-		}
-		goto del
-	} else if pair := strings.Split(literal, "-"); len(pair) == 2 {
-		low := net.ParseIP(pair[0])
-		high := net.ParseIP(pair[1])
-		if low == nil || high == nil {
-			return errors.New("Invalid IP range literal")
-		}
-		if (IsIPv4(low) && !IsIPv4(high)) || (IsIPv6(low) && !IsIPv6(high)) {
-			return errors.New("Invalid IP range literal: IPs format are different")
-		}
-		if IPToBigInt(low).Cmp(IPToBigInt(high)) >= 0 {
-			return errors.New("The left IP should be less than the right one")
-		}
-		goto del
+	lit, err := parseLiteral(literal)
+	if err != nil {
+		return err
+	}
+	if zone, ok := i.zones[lit.canonical]; ok {
+		i.zoneIndex(i.namespace).remove(zone.storage.Literal, zone.storage.Labels)
+		i.dropHolders(zone)
+		delete(i.zones, lit.canonical)
 	}
-	return errors.New("Invalid literal format")
-
-del:
-	delete(i.zones, literal)
-
 	return nil
 }
 
-func (i *ipam) RemoveZoneLabel(literal, key string) (string, bool) {
-	zone, zoneOk := i.zones[strings.ToLower(literal)]
-	if !zoneOk {
-		return "", zoneOk
+func (i *ipam) RemoveZoneLabel(literal, key string) (string, bool, error) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return "", false, err
 	}
 	value, keyOk := zone.storage.Labels[key]
+	if !keyOk {
+		return "", false, nil
+	}
+	labels := LabelMap(zone.storage.Labels).Copy()
+	delete(labels, key)
+	if err := i.validateLabels(zone, TargetZone, zone.storage.Labels, labels); err != nil {
+		return "", false, err
+	}
+	i.zoneIndex(i.namespace).remove(zone.storage.Literal, map[string]string{key: value})
 	delete(zone.storage.Labels, key)
-	return value, keyOk
+	return value, true, nil
 }
 
 func (i *ipam) ZoneLabels(literal string) (LabelMap, bool) {
-	zone, zoneOk := i.zones[strings.ToLower(literal)]
-	if !zoneOk {
-		return nil, zoneOk
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, false
 	}
 	if zone.storage.Labels == nil {
-		return nil, zoneOk
+		return nil, true
+	}
+	return LabelMap(zone.storage.Labels).Copy(), true
+}
+
+func (i *ipam) SetZoneNetworkConfig(literal string, config *NetworkConfig) error {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		zone.storage.Network = nil
+		return nil
+	}
+	normalized, err := normalizeNetworkConfig(config, zone)
+	if err != nil {
+		return err
+	}
+	zone.storage.Network = normalized
+	return nil
+}
+
+func (i *ipam) ZoneNetworkConfig(literal string) (*NetworkConfig, error) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, err
+	}
+	return copyNetworkConfig(zone.storage.Network), nil
+}
+
+func (i *ipam) SetZonePriority(literal string, priority int32, weight uint32) error {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
+	}
+	zone.storage.Priority = priority
+	zone.storage.Weight = weight
+	return nil
+}
+
+func (i *ipam) ZonePriority(literal string) (int32, uint32, error) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return 0, 0, err
 	}
-	return LabelMap(zone.storage.Labels).Copy(), zoneOk
+	return zone.storage.Priority, zone.storage.Weight, nil
+}
+
+func (i *ipam) SetAllocStrategy(strategy AllocStrategy) {
+	i.strategy = strategy
 }
 
 func (i *ipam) IdleCount() string {
-	usedCount := big.NewInt(int64(len(i.usedAddrs())))
-	reservedCount := big.NewInt(int64(len(i.reservedAddrs())))
-	totalCount := big.NewInt(0)
+	idleCount := big.NewInt(0)
 	for _, zone := range i.zones {
-		zoneTotal := big.NewInt(0).Sub(zone.end, zone.start)
-		zoneTotal.Add(zoneTotal, big.NewInt(1))
-		totalCount.Add(totalCount, zoneTotal)
+		// buckets failed to be paged in are not counted
+		i.touch(zone)
+		idleCount.Add(idleCount, zone.Stats().Idle)
 	}
-	return totalCount.Sub(totalCount, usedCount).Sub(totalCount, reservedCount).String()
+	return idleCount.String()
 }
 
-// FIXME: For IPv6 zone, there is a risk of reaching slice capacity
-func (i *ipam) usedAddrs() []string {
-	result := make([]string, 0)
-	for _, zone := range i.zones {
-		for _, b := range zone.storage.Buckets {
-			for addr := range b.Used {
-				result = append(result, addr)
-			}
+func (i *ipam) PageIn() error {
+	for _, zone := range sortedZones(i.zones) {
+		if err := i.touch(zone); err != nil {
+			return err
 		}
 	}
-	return result
+	return nil
 }
 
 func (i *ipam) UsedAddrs() []string {
-	return i.usedAddrs()
+	result := make([]string, 0)
+	// buckets failed to be paged in are not listed
+	i.RangeUsed(nil, func(entry *AddrEntry) bool {
+		result = append(result, entry.IP.String())
+		return true
+	})
+	return result
 }
 
-// FIXME: For IPv6 zone, there is a risk of reaching slice capacity
-func (i *ipam) reservedAddrs() []string {
+func (i *ipam) ReservedAddrs() []string {
 	result := make([]string, 0)
-	for _, zone := range i.zones {
-		for addr := range zone.storage.Reserved {
-			result = append(result, addr)
-		}
-	}
+	i.RangeReserved(nil, func(entry *AddrEntry) bool {
+		result = append(result, entry.IP.String())
+		return true
+	})
 	return result
 }
 
-func (i *ipam) ReservedAddrs() []string {
-	return i.reservedAddrs()
+func (i *ipam) allocAddrSpecific(specific string, labels LabelMap) (*zone, net.IP, error) {
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return nil, nil, err
+	}
+	if zone.IPReserved(ip) {
+		return nil, nil, fmt.Errorf("IP %s already reserved", specific)
+	}
+	old, _ := zone.GetAddrDesc(ip)
+	labels = zone.inherit(labels, old == nil)
+	err = i.validateAddrLabels(zone, old, func(merged LabelMap) {
+		for k, v := range labels {
+			merged[k] = v
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.checkUnique(zone, ip, labels); err != nil {
+		return nil, nil, err
+	}
+	if err := i.checkQuotas(zone, old, labels); err != nil {
+		return nil, nil, err
+	}
+	before, oldLabels := zone.Consumed(), zone.labelsOf(ip)
+	zone.AlocAddrWithCreateBucket(i.prefix, ip, labels)
+	i.changed(zone, ip, HistoryAlloc, oldLabels)
+	i.track(zone, before)
+	return zone, ip, nil
 }
 
 func (i *ipam) AllocAddrSpecific(specific string, labels LabelMap) error {
-	ip := net.ParseIP(specific)
-	if ip == nil {
-		return fmt.Errorf("Invalid IP format %s", specific)
+	_, _, err := i.allocAddrSpecific(specific, labels)
+	return err
+}
+
+func (i *ipam) allocAddrNext(labels LabelMap) (*zone, net.IP, error) {
+	if i.strategy == AllocByWeight {
+		// weighting needs the counts of all zones
+		for _, zone := range i.zones {
+			if err := i.touch(zone); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
-	for _, zone := range i.zones {
-		if (IsIPv4(ip) && zone.version == 6) || (!IsIPv4(ip) && zone.version == 4) {
+	// error of the last zone skipped because of label policies or quotas
+	var skipErr error
+	for _, zone := range allocOrder(i.zones, i.strategy) {
+		if err := i.touch(zone); err != nil {
+			return nil, nil, err
+		}
+		labels := zone.inherit(labels, true)
+		if err := i.validateLabels(zone, TargetAddr, nil, labels.Copy()); err != nil {
+			skipErr = err
 			continue
 		}
-		if !zone.Contains(ip) {
+		if err := i.checkUnique(zone, nil, labels); err != nil {
+			if !errors.Is(err, ErrLabelConflict) {
+				return nil, nil, err
+			}
+			skipErr = err
 			continue
 		}
-		if zone.IPReserved(ip) {
-			return fmt.Errorf("IP %s already reserved", specific)
+		if err := i.checkQuotas(zone, nil, labels); err != nil {
+			if !errors.Is(err, ErrQuotaExceeded) {
+				return nil, nil, err
+			}
+			skipErr = err
+			continue
 		}
-		zone.AlocAddrWithCreateBucket(i.prefix, ip, labels)
-		return nil
+		for _, r := range zone.intervals {
+			for tmp := new(big.Int).Set(r.start); tmp.Cmp(r.end) <= 0; tmp.Add(tmp, one) {
+				ip := BigIntToIP(tmp, zone.version)
+				if zone.IPUsed(ip) || zone.IPReserved(ip) {
+					continue
+				}
+				before := zone.Consumed()
+				zone.AlocAddrWithCreateBucket(i.prefix, ip, labels)
+				i.changed(zone, ip, HistoryAlloc, nil)
+				i.track(zone, before)
+				return zone, ip, nil
+			}
+		}
+	}
+	if skipErr != nil {
+		return nil, nil, skipErr
 	}
-	return fmt.Errorf("IP %s is not handled", specific)
+	return nil, nil, errors.New("No remained IP to allocate")
 }
 
 func (i *ipam) AllocAddrNext(labels LabelMap) (net.IP, error) {
-	for _, zone := range i.zones {
-		for tmp := new(big.Int).Add(zone.start, big.NewInt(0)); tmp.Cmp(zone.end) <= 0; tmp.Add(tmp, one) {
-			ip := BigIntToIP(tmp, zone.version)
-			if zone.IPUsed(ip) || zone.IPReserved(ip) {
-				continue
-			}
-			zone.AlocAddrWithCreateBucket(i.prefix, ip, labels)
-			return ip, nil
-		}
+	_, ip, err := i.allocAddrNext(labels)
+	return ip, err
+}
+
+func newAllocation(zone *zone, ip net.IP) *Allocation {
+	return &Allocation{IP: ip, Literal: zone.storage.Literal, Network: copyNetworkConfig(zone.storage.Network)}
+}
+
+func (i *ipam) AllocAddrSpecificWithConfig(specific string, labels LabelMap) (*Allocation, error) {
+	zone, ip, err := i.allocAddrSpecific(specific, labels)
+	if err != nil {
+		return nil, err
+	}
+	return newAllocation(zone, ip), nil
+}
+
+func (i *ipam) AllocAddrNextWithConfig(labels LabelMap) (*Allocation, error) {
+	zone, ip, err := i.allocAddrNext(labels)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("No remained IP to allocate")
+	return newAllocation(zone, ip), nil
 }
 
 func (i *ipam) ReserveAddr(specific string, labels LabelMap) error {
-	ip := net.ParseIP(specific)
-	if ip == nil {
-		return fmt.Errorf("Invalid IP format %s", specific)
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return err
 	}
-	for _, zone := range i.zones {
-		if (IsIPv4(ip) && zone.version == 6) || (!IsIPv4(ip) && zone.version == 4) {
-			continue
-		}
-		if !zone.Contains(ip) {
-			continue
-		}
-		if zone.IPUsed(ip) {
-			return fmt.Errorf("IP %s is in use", specific)
-		}
-		if zone.IPReserved(ip) {
-			return fmt.Errorf("IP %s already reserved", specific)
-		}
-		if zone.storage.Reserved == nil {
-			zone.storage.Reserved = make(map[string]*Descriptor)
-		}
-		zone.storage.Reserved[ip.String()] = &Descriptor{Labels: labels.Copy()}
-		return nil
+	if zone.IPUsed(ip) {
+		return fmt.Errorf("IP %s is in use", specific)
+	}
+	if zone.IPReserved(ip) {
+		return fmt.Errorf("IP %s already reserved", specific)
+	}
+	labels = zone.inherit(labels, true)
+	if err := i.validateLabels(zone, TargetAddr, nil, labels.Copy()); err != nil {
+		return err
+	}
+	if err := i.checkUnique(zone, nil, labels); err != nil {
+		return err
+	}
+	if err := i.checkQuotas(zone, nil, labels); err != nil {
+		return err
+	}
+	before := zone.Consumed()
+	zone.ReserveAddr(ip, &Descriptor{Labels: labels.Copy(), Revision: 1})
+	i.changed(zone, ip, HistoryReserve, nil)
+	i.track(zone, before)
+	return nil
+}
+
+func (i *ipam) ClaimReserved(specific string, labels LabelMap) error {
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return err
+	}
+	if !zone.IPReserved(ip) {
+		return fmt.Errorf("IP %s not reserved", specific)
+	}
+	reserved, _ := zone.Describe(ip)
+	merged := LabelMap(reserved.Labels).Copy()
+	for k, v := range labels {
+		merged[k] = v
+	}
+	merged = zone.inherit(merged, false)
+	if err := i.validateLabels(zone, TargetAddr, reserved.Labels, merged); err != nil {
+		return err
+	}
+	if err := i.checkUnique(zone, ip, merged); err != nil {
+		return err
+	}
+	if err := i.checkQuotas(zone, reserved, merged); err != nil {
+		return err
 	}
-	return fmt.Errorf("IP %s is not handled", specific)
+	before := zone.Consumed()
+	zone.ReleaseAddrWithDeleteBucket(ip)
+	zone.AlocAddrWithCreateBucket(i.prefix, ip, merged)
+	// the revision goes on from the one of reservation
+	desc, _ := zone.GetAddrDesc(ip)
+	desc.Revision = reserved.Revision + 1
+	desc.Annotations = reserved.Annotations
+	desc.History = reserved.History
+	i.changed(zone, ip, HistoryClaim, reserved.Labels)
+	i.track(zone, before)
+	return nil
 }
 
 func (i *ipam) ReleaseAddr(specific string) error {
-	ip := net.ParseIP(specific)
-	if ip == nil {
-		return fmt.Errorf("Invalid IP format %s", specific)
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return err
 	}
-	for _, zone := range i.zones {
-		if !zone.Contains(ip) {
-			continue
-		}
-		// 无差别尝试移除
-		zone.ReleaseAddrWithDeleteBucket(ip)
-		return nil
+	before, oldLabels := zone.Consumed(), zone.labelsOf(ip)
+	// 无差别尝试移除
+	if desc := zone.ReleaseAddrWithDeleteBucket(ip); desc != nil {
+		zone.retire(i.prefix, ip, desc)
 	}
-	return fmt.Errorf("IP %s is not handled", specific)
+	if oldLabels != nil {
+		i.changed(zone, ip, HistoryRelease, oldLabels)
+	}
+	i.track(zone, before)
+	return nil
 }
 
 func (i *ipam) SetAddrLabel(specific, key, value string) error {
-	ip := net.ParseIP(specific)
-	if ip == nil {
-		return fmt.Errorf("Invalid IP format %s", specific)
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return err
 	}
-	for _, zone := range i.zones {
-		if zone.SetAddrLabel(ip, key, value) {
-			return nil
-		}
+	desc, ok := zone.Describe(ip)
+	if !ok {
+		return fmt.Errorf("IP %s not allocated or reserved", specific)
+	}
+	if err := zone.checkEnforced(ip, key); err != nil {
+		return err
+	}
+	err = i.validateAddrLabels(zone, desc, func(labels LabelMap) {
+		labels[key] = value
+	})
+	if err != nil {
+		return err
+	}
+	if err := i.checkUnique(zone, ip, LabelMap{key: value}); err != nil {
+		return err
 	}
-	return fmt.Errorf("IP %s not allocated", specific)
+	updated := LabelMap(desc.Labels).Copy()
+	updated[key] = value
+	if err := i.checkQuotas(zone, desc, updated); err != nil {
+		return err
+	}
+	oldLabels := LabelMap(desc.Labels).Copy()
+	zone.SetAddrLabel(ip, key, value)
+	i.changed(zone, ip, HistoryLabel, oldLabels)
+	return nil
 }
 
 func (i *ipam) RemoveAddrLabel(specific, key string) error {
-	ip := net.ParseIP(specific)
-	if ip == nil {
-		return fmt.Errorf("Invalid IP format %s", specific)
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return err
 	}
-	for _, zone := range i.zones {
-		if zone.RemoveAddrLabel(ip, key) {
-			return nil
+	desc, ok := zone.Describe(ip)
+	if !ok {
+		return fmt.Errorf("IP %s not allocated or reserved", specific)
+	}
+	if err := zone.checkEnforced(ip, key); err != nil {
+		return err
+	}
+	err = i.validateAddrLabels(zone, desc, func(labels LabelMap) {
+		delete(labels, key)
+	})
+	if err != nil {
+		return err
+	}
+	oldLabels := LabelMap(desc.Labels).Copy()
+	zone.RemoveAddrLabel(ip, key)
+	i.changed(zone, ip, HistoryLabel, oldLabels)
+	return nil
+}
+
+func (i *ipam) UpdateAddrLabels(specific string, set LabelMap, remove []string, expectedRevision uint64,
+	mode UpdateMode) (uint64, error) {
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return 0, err
+	}
+	desc, ok := zone.Describe(ip)
+	if !ok {
+		return 0, fmt.Errorf("IP %s not allocated or reserved", specific)
+	}
+	if expectedRevision != 0 && desc.Revision != expectedRevision {
+		return 0, fmt.Errorf("%w: IP %s is at revision %d, expected %d", ErrRevisionConflict, specific,
+			desc.Revision, expectedRevision)
+	}
+	update := func(labels LabelMap) {
+		if mode == UpdateReplace {
+			for k := range labels {
+				delete(labels, k)
+			}
+		} else {
+			for _, k := range remove {
+				delete(labels, k)
+			}
 		}
+		for k, v := range set {
+			labels[k] = v
+		}
+	}
+	updated := LabelMap(desc.Labels).Copy()
+	update(updated)
+	for _, k := range zone.storage.Defaults.GetEnforced() {
+		if value, ok := updated[k]; !ok || value != desc.Labels[k] {
+			if err := zone.checkEnforced(ip, k); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := i.validateLabels(zone, TargetAddr, desc.Labels, updated); err != nil {
+		return 0, err
 	}
-	return fmt.Errorf("IP %s not allocated", specific)
+	if err := i.checkUnique(zone, ip, updated); err != nil {
+		return 0, err
+	}
+	if err := i.checkQuotas(zone, desc, updated); err != nil {
+		return 0, err
+	}
+	oldLabels := LabelMap(desc.Labels).Copy()
+	zone.Update(ip, func(desc *Descriptor) {
+		desc.Labels = updated
+	})
+	i.changed(zone, ip, HistoryLabel, oldLabels)
+	return desc.Revision, nil
 }
 
 func (i *ipam) AddrLabels(specific string) (LabelMap, error) {
-	ip := net.ParseIP(specific)
-	if ip == nil {
-		return nil, fmt.Errorf("Invalid IP format %s", specific)
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return nil, err
 	}
-	for _, zone := range i.zones {
-		if desc, ok := zone.GetAddrDesc(ip); ok {
-			return LabelMap(desc.Labels).Copy(), nil
-		}
+	if desc, ok := zone.Describe(ip); ok {
+		return zone.resolve(desc.Labels), nil
 	}
-	return nil, fmt.Errorf("IP %s not allocated", specific)
+	return nil, fmt.Errorf("IP %s not allocated or reserved", specific)
 }
 
 func (i *ipam) FindLiteral(specific string) string {
@@ -385,65 +708,77 @@ func (i *ipam) FindLiteral(specific string) string {
 	if ip == nil {
 		return ""
 	}
-	for _, zone := range i.zones {
-		if zone.Contains(ip) {
-			return zone.storage.Literal
-		}
+	if zone := i.zoneOf(ip); zone != nil {
+		return zone.storage.Literal
 	}
 	return ""
 }
 
 func (i *ipam) Literals() []string {
 	results := make([]string, 0)
-	for _, zone := range i.zones {
+	for _, zone := range sortedZones(i.zones) {
 		results = append(results, zone.storage.Literal)
 	}
 	return results
 }
 
 func (i *ipam) Dump(fat bool) ([]byte, error) {
+	return i.DumpWithOptions(&DumpOptions{Fat: fat})
+}
+
+func (i *ipam) DumpWithOptions(opts *DumpOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &DumpOptions{}
+	}
+	fat := opts.Fat
 	var resize func(*zone) *Zone
 	if fat {
 		resize = func(zone *zone) *Zone {
-			storage := zone.storage
-			return &Zone{
-				Literal:  storage.Literal,
-				Labels:   storage.Labels,
-				Buckets:  storage.Buckets,
-				Reserved: storage.Reserved,
-			}
+			return zone.storage
 		}
 	} else {
 		resize = func(zone *zone) *Zone {
-			storage := zone.storage
 			emptyBuckets := make(map[string]*Bucket)
 			// 只保留key，用于以后索引
-			for key := range storage.Buckets {
+			for key := range zone.storage.Buckets {
 				emptyBuckets[key] = nil
 			}
-			return &Zone{
-				Literal:  storage.Literal,
-				Labels:   storage.Labels,
-				Buckets:  emptyBuckets,
-				Reserved: storage.Reserved,
+			thin := *zone.storage
+			thin.Buckets = emptyBuckets
+			if opts.OmitAnnotations {
+				thin.Annotations = nil
+				thin.Reserved = withoutAnnotations(thin.Reserved)
 			}
+			return &thin
 		}
 	}
 	// 生成一个Block，把所有zone对应的Zone放入Block，最后做Marshal
 	block := &Block{
-		Labels: i.labels.Copy(),
-		Zones:  make([]*Zone, 0),
+		Labels:     i.labels.Copy(),
+		Zones:      make([]*Zone, 0),
+		Thresholds: i.thresholds,
 	}
-	for _, zone := range i.zones {
+	for _, name := range i.sortedNamespaces() {
+		block.Quotas = append(block.Quotas, sortedQuotas(i.quotas[name])...)
+	}
+	for _, name := range i.sortedNamespaces() {
+		block.UniqueLabels = append(block.UniqueLabels, sortedUniqueLabels(i.uniques[name])...)
+	}
+	for _, zone := range i.allZones() {
+		if fat {
+			if err := i.touch(zone); err != nil {
+				return nil, err
+			}
+		}
 		block.Zones = append(block.Zones, resize(zone))
 	}
 	return block.Marshal()
 }
 
 func (i *ipam) DumpZoneAddrs(literal string, onlyKeys bool) (map[string][]byte, error) {
-	zone, zoneOk := i.zones[strings.ToLower(literal)]
-	if !zoneOk {
-		return nil, fmt.Errorf("IP Lliteral %s not exists", literal)
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, err
 	}
 	result := make(map[string][]byte)
 	if onlyKeys {
@@ -451,7 +786,14 @@ func (i *ipam) DumpZoneAddrs(literal string, onlyKeys bool) (map[string][]byte,
 			result[key] = nil
 		}
 	} else {
+		if err := i.touch(zone); err != nil {
+			return nil, err
+		}
 		for key, b := range zone.storage.Buckets {
+			// buckets not loaded yet are skipped
+			if b == nil {
+				continue
+			}
 			raw, err := b.Marshal()
 			if err != nil {
 				return nil, fmt.Errorf("Marshal IP failed: %s", err)
@@ -462,74 +804,26 @@ func (i *ipam) DumpZoneAddrs(literal string, onlyKeys bool) (map[string][]byte,
 	return result, nil
 }
 
-func (i *ipam) loadZoneSingle(z *Zone, lazy bool) *ipam {
-	ip := net.ParseIP(z.Literal)
-	ipBigInt := IPToBigInt(ip)
-	zone := &zone{start: ipBigInt, end: ipBigInt, lazy: lazy, storage: z, version: 6}
-	if IsIPv4(ip) {
-		zone.version = 4
+func (i *ipam) loadZone(z *Zone, lazy bool) error {
+	lit, err := parseLiteral(z.Literal)
+	if err != nil {
+		return err
 	}
-	i.zones[z.Literal] = zone
-	return i
-}
-
-func (i *ipam) loadZoneCIDR(z *Zone, lazy bool) *ipam {
-	ip, cidr, _ := net.ParseCIDR(z.Literal)
-	ones, _ := cidr.Mask.Size()
-	local := IPToBigInt(cidr.IP)
-	lsh := uint(128 - ones)
-	version := uint8(6)
-	if IsIPv4(ip) {
-		lsh = uint(32 - ones)
-		version = 4
+	z.Literal = lit.canonical
+	zones, idx := i.namespaceZones(z.Namespace), i.zoneIndex(z.Namespace)
+	zone := newZone(lit, z, lazy)
+	if old, ok := zones[z.Literal]; ok {
+		idx.remove(old.storage.Literal, old.storage.Labels)
+		// policies are not persisted, keep the one of the replaced zone
+		zone.policy = old.policy
 	}
-	offset := new(big.Int).Sub(new(big.Int).Lsh(one, lsh), one)
-	start := new(big.Int).Add(local, one)
-	end := new(big.Int).Sub(new(big.Int).Add(local, offset), one)
-	zone := &zone{start: start, end: end, lazy: lazy, storage: z, version: version}
-	i.zones[z.Literal] = zone
-	return i
+	zones[z.Literal] = zone
+	idx.add(z.Literal, z.Labels)
+	return nil
 }
 
-func (i *ipam) loadZoneRange(z *Zone, lazy bool) *ipam {
-	pair := strings.Split(z.Literal, "-")
-	ip0, ip1 := net.ParseIP(pair[0]), net.ParseIP(pair[1])
-	start := IPToBigInt(ip0)
-	end := IPToBigInt(ip1)
-	zone := &zone{start: start, end: end, lazy: lazy, storage: z}
-	if IsIPv4(ip0) {
-		zone.version = 4
-	}
-	i.zones[z.Literal] = zone
-	return i
-}
-
-func (i *ipam) loadZone(z *Zone, lazy bool) error {
-	if single := net.ParseIP(z.Literal); single != nil {
-		i.loadZoneSingle(z, lazy)
-	} else if ip, ipnet, err := net.ParseCIDR(z.Literal); err == nil {
-		if !ip.Equal(ipnet.IP) {
-			return errors.New("Invalid CIDR network value")
-		}
-		i.loadZoneCIDR(z, lazy)
-	} else if pair := strings.Split(z.Literal, "-"); len(pair) == 2 {
-		low := net.ParseIP(pair[0])
-		high := net.ParseIP(pair[1])
-		if low == nil || high == nil {
-			return errors.New("Invalid IP range value")
-		}
-		if (IsIPv4(low) && !IsIPv4(high)) || (IsIPv6(low) && !IsIPv6(high)) {
-			return errors.New("Invalid IP range value: IPs format are different")
-		}
-		if IPToBigInt(low).Cmp(IPToBigInt(high)) >= 0 {
-			return errors.New("The left IP should be less than the right one")
-		}
-		i.loadZoneRange(z, lazy)
-	} else {
-		return errors.New("Invalid format")
-	}
-
-	return nil
+func (i *ipam) SetBucketLoader(loader BucketLoader) {
+	i.loader = loader
 }
 
 func (i *ipam) Load(raw []byte) error {
@@ -537,6 +831,24 @@ func (i *ipam) Load(raw []byte) error {
 	if err := block.Unmarshal(raw); err != nil {
 		return err
 	}
+	for k, v := range block.Labels {
+		i.labels[k] = v
+	}
+	if len(block.Thresholds) > 0 {
+		i.thresholds = block.Thresholds
+	}
+	for _, quota := range block.Quotas {
+		if i.quotas[quota.Namespace] == nil {
+			i.quotas[quota.Namespace] = make(map[string]*Quota)
+		}
+		i.quotas[quota.Namespace][quota.Name] = quota
+	}
+	for _, unique := range block.UniqueLabels {
+		if i.uniques[unique.Namespace] == nil {
+			i.uniques[unique.Namespace] = make(map[string]*UniqueLabel)
+		}
+		i.uniques[unique.Namespace][unique.Key] = unique
+	}
 	for _, z := range block.Zones {
 		if z.Buckets == nil {
 			z.Buckets = make(map[string]*Bucket)
@@ -544,7 +856,7 @@ func (i *ipam) Load(raw []byte) error {
 		if z.Reserved == nil {
 			z.Reserved = make(map[string]*Descriptor)
 		}
-		if err := i.loadZone(z, false); err != nil {
+		if err := i.loadZone(z, z.Lazy); err != nil {
 			return err
 		}
 	}
@@ -552,9 +864,9 @@ func (i *ipam) Load(raw []byte) error {
 }
 
 func (i *ipam) LoadZoneAddrs(literal string, addrs map[string][]byte, force bool) error {
-	zone, zoneOk := i.zones[strings.ToLower(literal)]
-	if !zoneOk {
-		return fmt.Errorf("IP Lliteral %s not exists", literal)
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
 	}
 	temp := make(map[string]*Bucket)
 	for key, raw := range addrs {
@@ -575,7 +887,15 @@ func (i *ipam) LoadZoneAddrs(literal string, addrs map[string][]byte, force bool
 		temp[key] = bucket
 	}
 	for key, b := range temp {
+		zone.unindexBucket(zone.storage.Buckets[key])
 		zone.storage.Buckets[key] = b
+		zone.indexBucket(b)
+	}
+	if len(temp) > 0 {
+		i.dropHolders(zone)
+		for _, unique := range i.uniqueLabels(zone.storage.Namespace) {
+			holdIndexed(unique, zone)
+		}
 	}
 	return nil
 }
@@ -1,10 +1,12 @@
 package ipam
 
 import (
+	"errors"
 	"math/big"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestBasic(t *testing.T) {
@@ -113,7 +115,7 @@ func TestLabel(t *testing.T) {
 		t.Fatal("Wrong IPAM label value")
 	}
 	ipam.SetLabel(foo, bar2)
-	if value, _ := ipam.RemoveLabel(foo); value != bar2 {
+	if value, _, err := ipam.RemoveLabel(foo); err != nil || value != bar2 {
 		t.Fatal("Wrong IPAM label value from removing a key")
 	}
 
@@ -124,7 +126,7 @@ func TestLabel(t *testing.T) {
 	if err := ipam.SetZoneLabel(literal, foo, bar); err != nil {
 		t.Fatal(err)
 	}
-	if value, _ := ipam.RemoveZoneLabel(literal, foo); value != bar {
+	if value, _, err := ipam.RemoveZoneLabel(literal, foo); err != nil || value != bar {
 		t.Fatal("Wrong zone label value from removing a key")
 	}
 
@@ -205,3 +207,307 @@ func BenchmarkAllocNext(b *testing.B) {
 		ipam.AllocAddrNext(nil)
 	}
 }
+
+func TestLazyLoad(t *testing.T) {
+	AddrNumPerBucket = 16
+	defer func() {
+		AddrNumPerBucket = 4096
+	}()
+
+	ipam1 := New("test", nil)
+	ipam1.AddZone("192.168.1.0/24", true)
+	ipam1.AddZone("192.168.2.0/24", true)
+	for i := 1; i <= 40; i++ {
+		ipam1.AllocAddrSpecific("192.168.1."+strconv.Itoa(i), map[string]string{"foo": "bar"})
+		ipam1.AllocAddrSpecific("192.168.2."+strconv.Itoa(i), nil)
+	}
+	rawBlock, err := ipam1.Dump(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := make(map[string][]byte)
+	for _, literal := range ipam1.Literals() {
+		addrs, err := ipam1.DumpZoneAddrs(literal, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, raw := range addrs {
+			stored[key] = raw
+		}
+	}
+
+	fetched := make(map[string]bool)
+	ipam2 := New("test", nil)
+	ipam2.SetBucketLoader(BucketLoaderFunc(func(key string) ([]byte, error) {
+		fetched[key] = true
+		return stored[key], nil
+	}))
+	if err := ipam2.Load(rawBlock); err != nil {
+		t.Fatal(err)
+	}
+	if len(fetched) != 0 {
+		t.Fatal("No bucket should be fetched before access")
+	}
+	labels, err := ipam2.AddrLabels("192.168.1.33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels["foo"] != "bar" {
+		t.Fatal("Wrong labels of lazy loaded addr")
+	}
+	if len(fetched) != 3 {
+		t.Fatalf("Only 3 buckets of the touched zone should be fetched, got %d", len(fetched))
+	}
+	if err := ipam2.AllocAddrSpecific("192.168.2.41", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(fetched) != 6 {
+		t.Fatalf("All 6 buckets should be fetched, got %d", len(fetched))
+	}
+	if idleCount := ipam2.IdleCount(); idleCount != "427" {
+		t.Fatalf("Wrong idle count %s", idleCount)
+	}
+	if err := ipam2.PageIn(); err != nil {
+		t.Fatal(err)
+	}
+
+	ipam3 := New("test", nil)
+	if err := ipam3.Load(rawBlock); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam3.PageIn(); err == nil {
+		t.Fatal("Paging in should fail without bucket loader")
+	}
+	if _, err := ipam3.AddrLabels("192.168.1.33"); err == nil {
+		t.Fatal("Addr labels should fail without bucket loader")
+	}
+}
+
+func TestAllocOrder(t *testing.T) {
+	ipam := New("test", nil)
+	for _, literal := range []string{"FE80::1-FE80::4", "192.168.2.1-192.168.2.4", "192.168.1.1-192.168.1.4"} {
+		if err := ipam.AddZone(literal, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// same priority, ordered by address
+	if ip, _ := ipam.AllocAddrNext(nil); ip.String() != "192.168.1.1" {
+		t.Fatalf("Wrong allocated IP %s", ip)
+	}
+	if err := ipam.SetZonePriority("FE80::1-FE80::4", 10, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.SetZonePriority("192.168.2.1-4", 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if ip, _ := ipam.AllocAddrNext(nil); ip.String() != "fe80::1" {
+		t.Fatalf("Wrong allocated IP %s", ip)
+	}
+
+	ipam.SetAllocStrategy(AllocByWeight)
+	counts := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		ip, err := ipam.AllocAddrNext(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[ipam.FindLiteral(ip.String())]++
+	}
+	// consumed counts become 4:1:1 with weights 3:1:1
+	if counts["fe80::1-fe80::4"] != 3 || counts["192.168.1.1-192.168.1.4"] != 0 || counts["192.168.2.1-192.168.2.4"] != 1 {
+		t.Fatalf("Wrong weighted allocation %v", counts)
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	literal := "10.0.0.0/16"
+	ipam1 := New("test", nil)
+	tenantA, tenantB := ipam1.Namespace("a"), ipam1.Namespace("b")
+	if err := tenantA.AddZone(literal, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantB.AddZone(literal, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantB.AddZone("10.0.1.0/24", true); err == nil {
+		t.Fatal("Literal should be overlapped in the same namespace")
+	}
+	if literal := ipam1.FindLiteral("10.0.0.1"); literal != "" {
+		t.Fatal("Default namespace should have no zone")
+	}
+	if err := tenantA.AllocAddrSpecific("10.0.0.1", map[string]string{"tenant": "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantB.ReserveAddr("10.0.0.1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if ip, _ := tenantB.AllocAddrNext(nil); ip.String() != "10.0.0.2" {
+		t.Fatalf("Wrong allocated IP %s", ip)
+	}
+	if names := ipam1.Namespaces(); len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Fatalf("Wrong namespaces %v", names)
+	}
+	keys, err := tenantB.DumpZoneAddrs(literal, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := range keys {
+		if !strings.HasPrefix(key, "test/b/"+literal+"/") {
+			t.Fatalf("Wrong bucket key %s", key)
+		}
+	}
+
+	raw, err := ipam1.Dump(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ipam2 := New("test", nil)
+	if err := ipam2.Load(raw); err != nil {
+		t.Fatal(err)
+	}
+	labels, err := ipam2.Namespace("a").AddrLabels("10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels["tenant"] != "a" {
+		t.Fatal("Wrong labels of loaded addr")
+	}
+	if reserved := ipam2.Namespace("b").ReservedAddrs(); len(reserved) != 1 {
+		t.Fatal("Reserved addr should be loaded into namespace b")
+	}
+	if len(ipam2.Literals()) != 0 {
+		t.Fatal("Default namespace should have no zone")
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	AddrNumPerBucket = 4
+	// history entries of identical state have identical timestamps
+	now = func() time.Time {
+		return time.Unix(1600000000, 0)
+	}
+	defer func() {
+		AddrNumPerBucket = 4096
+		now = time.Now
+	}()
+
+	build := func() IPAM {
+		ipam := New("test", map[string]string{"a": "1", "b": "2", "c": "3"})
+		for _, literal := range []string{"FE80::/64", "10.0.0.10", "10.0.0.2", "10.0.1.0/24"} {
+			ipam.AddZone(literal, false)
+			ipam.Namespace("vrf").AddZone(literal, false)
+		}
+		for i := 1; i <= 20; i++ {
+			ipam.AllocAddrNext(map[string]string{"x": strconv.Itoa(i), "y": "1", "z": "2"})
+		}
+		ipam.ReleaseAddr("10.0.1.3")
+		ipam.ReserveAddr("10.0.1.30", map[string]string{"x": "1", "y": "2"})
+		ipam.ReserveAddr("10.0.1.31", nil)
+		ipam.SetQuota(&Quota{Name: "b", Key: "x", Value: "1", Limit: 2})
+		ipam.SetQuota(&Quota{Name: "a", Key: "x", Value: "2", Limit: 2})
+		return ipam
+	}
+	ipam1, ipam2 := build(), build()
+	literals := ipam1.Literals()
+	if len(literals) != 4 || literals[0] != "10.0.0.2" || literals[1] != "10.0.0.10" || literals[3] != "fe80::/64" {
+		t.Fatalf("Wrong literals order %v", literals)
+	}
+	if used := ipam1.UsedAddrs(); used[0] != "10.0.0.2" || used[1] != "10.0.0.10" || used[2] != "10.0.1.1" {
+		t.Fatalf("Wrong used addrs order %v", used)
+	}
+	for _, fat := range []bool{true, false} {
+		raw1, _ := ipam1.Dump(fat)
+		raw2, _ := ipam2.Dump(fat)
+		if string(raw1) != string(raw2) {
+			t.Fatal("Dumps of identical state should be identical")
+		}
+		raw3, _ := ipam1.Dump(fat)
+		if string(raw1) != string(raw3) {
+			t.Fatal("Dumps of the same IPAM should be identical")
+		}
+	}
+}
+
+func TestClaimReserved(t *testing.T) {
+	ipam := New("test", nil)
+	ipam.AddZone("10.0.0.0/24", true)
+	ipam.ReserveAddr("10.0.0.10", map[string]string{"app": "web", "env": "dev"})
+	ipam.ReserveAddr("10.0.0.11", nil)
+
+	if err := ipam.SetAddrLabel("10.0.0.10", "owner", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.RemoveAddrLabel("10.0.0.10", "app"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.SetAddrLabel("10.0.0.11", "owner", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	labels, err := ipam.AddrLabels("10.0.0.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 2 || labels["owner"] != "bob" || labels["env"] != "dev" {
+		t.Fatalf("Wrong labels of reserved addr %v", labels)
+	}
+	if err := ipam.SetAddrLabel("10.0.0.12", "owner", "bob"); err == nil {
+		t.Fatal("Free addr should not be labeled")
+	}
+
+	if err := ipam.ClaimReserved("10.0.0.12", nil); err == nil {
+		t.Fatal("Free addr should not be claimed")
+	}
+	if err := ipam.ClaimReserved("10.0.0.10", map[string]string{"env": "prod"}); err != nil {
+		t.Fatal(err)
+	}
+	info, _ := ipam.AddrInfo("10.0.0.10")
+	if info.State != AddrUsed || info.RefCount != 1 || info.Labels["owner"] != "bob" || info.Labels["env"] != "prod" {
+		t.Fatalf("Wrong info of claimed addr %v", info)
+	}
+	if err := ipam.ClaimReserved("10.0.0.10", nil); err == nil {
+		t.Fatal("Used addr should not be claimed")
+	}
+}
+
+func TestUpdateAddrLabels(t *testing.T) {
+	ipam := New("test", nil)
+	ipam.AddZone("10.0.0.0/24", true)
+	ipam.AllocAddrSpecific("10.0.0.1", map[string]string{"a": "1", "b": "2"})
+	info, _ := ipam.AddrInfo("10.0.0.1")
+	if info.Revision != 1 {
+		t.Fatalf("Revision of new addr should be 1, got %d", info.Revision)
+	}
+	revision, err := ipam.UpdateAddrLabels("10.0.0.1", map[string]string{"c": "3", "a": "0"}, []string{"b"}, 1, UpdateMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels, _ := ipam.AddrLabels("10.0.0.1")
+	if revision != 2 || len(labels) != 2 || labels["a"] != "0" || labels["c"] != "3" {
+		t.Fatalf("Wrong merged labels %v at revision %d", labels, revision)
+	}
+	if _, err := ipam.UpdateAddrLabels("10.0.0.1", map[string]string{"d": "4"}, nil, 1, UpdateMerge); !errors.Is(err, ErrRevisionConflict) {
+		t.Fatalf("Stale revision should conflict, got %v", err)
+	}
+	ipam.SetAddrLabel("10.0.0.1", "e", "5")
+	revision, err = ipam.UpdateAddrLabels("10.0.0.1", map[string]string{"d": "4"}, []string{"a"}, 3, UpdateReplace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels, _ = ipam.AddrLabels("10.0.0.1")
+	if revision != 4 || len(labels) != 1 || labels["d"] != "4" {
+		t.Fatalf("Wrong replaced labels %v at revision %d", labels, revision)
+	}
+	if revision, _ = ipam.UpdateAddrLabels("10.0.0.1", nil, []string{"d"}, 0, UpdateMerge); revision != 5 {
+		t.Fatalf("Revision 0 should not be checked, got %d", revision)
+	}
+
+	ipam.ReserveAddr("10.0.0.2", map[string]string{"a": "1"})
+	ipam.UpdateAddrLabels("10.0.0.2", map[string]string{"b": "2"}, nil, 1, UpdateMerge)
+	ipam.ClaimReserved("10.0.0.2", nil)
+	if info, _ := ipam.AddrInfo("10.0.0.2"); info.Revision != 3 || len(info.Labels) != 2 {
+		t.Fatalf("Claimed addr should keep labels and revision, got %v", info)
+	}
+	if _, err := ipam.UpdateAddrLabels("10.0.0.3", nil, nil, 0, UpdateMerge); err == nil {
+		t.Fatal("Free addr should not be updated")
+	}
+}
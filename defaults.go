@@ -0,0 +1,95 @@
+package ipam
+
+import (
+	"fmt"
+	"net"
+)
+
+func copyZoneDefaults(defaults *ZoneDefaults) *ZoneDefaults {
+	if defaults == nil {
+		return nil
+	}
+	return &ZoneDefaults{
+		Labels:        LabelMap(defaults.Labels).Copy(),
+		Enforced:      append([]string(nil), defaults.Enforced...),
+		ResolveOnRead: defaults.ResolveOnRead,
+	}
+}
+
+// enforces return true if the default value of key overrides the values given by callers
+func (z *zone) enforces(key string) bool {
+	return contains(z.storage.Defaults.GetEnforced(), key)
+}
+
+// inherit return the labels stored with an addr allocated or reserved with labels,
+// defaults are only added to fresh addrs while enforced keys are applied to all
+func (z *zone) inherit(labels LabelMap, fresh bool) LabelMap {
+	defaults := z.storage.Defaults
+	if defaults == nil || defaults.ResolveOnRead {
+		return labels
+	}
+	result := make(LabelMap)
+	if fresh {
+		for k, v := range defaults.Labels {
+			result[k] = v
+		}
+	}
+	for k, v := range labels {
+		result[k] = v
+	}
+	for _, k := range defaults.Enforced {
+		result[k] = defaults.Labels[k]
+	}
+	return result
+}
+
+// resolve return the labels of an addr read by callers, which are the stored labels
+// with the inherited ones if defaults are resolved on read
+func (z *zone) resolve(labels LabelMap) LabelMap {
+	defaults := z.storage.Defaults
+	if defaults == nil || !defaults.ResolveOnRead {
+		return labels.Copy()
+	}
+	result := LabelMap(defaults.Labels).Copy()
+	for k, v := range labels {
+		result[k] = v
+	}
+	for _, k := range defaults.Enforced {
+		result[k] = defaults.Labels[k]
+	}
+	return result
+}
+
+// checkEnforced return an error if key of an addr of zone is enforced by the defaults stored with addrs
+func (z *zone) checkEnforced(ip net.IP, key string) error {
+	if z.enforces(key) && !z.storage.Defaults.ResolveOnRead {
+		return fmt.Errorf("Label %s of IP %s is enforced by zone %s", key, ip, z.storage.Literal)
+	}
+	return nil
+}
+
+func (i *ipam) SetZoneDefaults(literal string, defaults *ZoneDefaults) error {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
+	}
+	if defaults == nil {
+		zone.storage.Defaults = nil
+		return nil
+	}
+	for _, k := range defaults.Enforced {
+		if _, ok := defaults.Labels[k]; !ok {
+			return fmt.Errorf("Enforced label %s has no default value", k)
+		}
+	}
+	zone.storage.Defaults = copyZoneDefaults(defaults)
+	return nil
+}
+
+func (i *ipam) ZoneDefaults(literal string) (*ZoneDefaults, error) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, err
+	}
+	return copyZoneDefaults(zone.storage.Defaults), nil
+}
@@ -0,0 +1,73 @@
+package ipam
+
+import "testing"
+
+func TestZoneDefaults(t *testing.T) {
+	ipam := New("test", nil)
+	ipam.AddZone("10.0.0.0/24", true)
+	if err := ipam.SetZoneDefaults("10.0.0.0/24", &ZoneDefaults{Enforced: []string{"rack"}}); err == nil {
+		t.Fatal("Enforced label without default value should be rejected")
+	}
+	defaults := &ZoneDefaults{Labels: map[string]string{"rack": "a3", "env": "dev"}, Enforced: []string{"rack"}}
+	if err := ipam.SetZoneDefaults("10.0.0.0/24", defaults); err != nil {
+		t.Fatal(err)
+	}
+	defaults.Labels["rack"] = "b1"
+	ipam.AllocAddrSpecific("10.0.0.1", map[string]string{"env": "prod", "rack": "x"})
+	ipam.ReserveAddr("10.0.0.2", nil)
+	ip, _ := ipam.AllocAddrNext(map[string]string{"app": "web"})
+	for addr, expected := range map[string]LabelMap{
+		"10.0.0.1":  {"rack": "a3", "env": "prod"},
+		"10.0.0.2":  {"rack": "a3", "env": "dev"},
+		ip.String(): {"rack": "a3", "env": "dev", "app": "web"},
+	} {
+		labels, _ := ipam.AddrLabels(addr)
+		if len(labels) != len(expected) || !labels.Includes(expected) {
+			t.Fatalf("Labels of %s should be %v, got %v", addr, expected, labels)
+		}
+	}
+	if err := ipam.SetAddrLabel("10.0.0.1", "rack", "x"); err == nil {
+		t.Fatal("Enforced label should not be changed")
+	}
+	if err := ipam.RemoveAddrLabel("10.0.0.1", "env"); err != nil {
+		t.Fatal(err)
+	}
+	ipam.AllocAddrSpecific("10.0.0.1", nil)
+	if labels, _ := ipam.AddrLabels("10.0.0.1"); len(labels) != 1 {
+		t.Fatalf("Defaults should not be added to used addrs again, got %v", labels)
+	}
+
+	ipam.SetZoneDefaults("10.0.0.0/24", &ZoneDefaults{
+		Labels:        map[string]string{"rack": "b1", "env": "dev"},
+		Enforced:      []string{"rack"},
+		ResolveOnRead: true,
+	})
+	ipam.AllocAddrSpecific("10.0.0.10", map[string]string{"env": "prod", "rack": "x"})
+	labels, _ := ipam.AddrLabels("10.0.0.10")
+	if len(labels) != 2 || labels["rack"] != "b1" || labels["env"] != "prod" {
+		t.Fatalf("Inherited labels should be resolved on read, got %v", labels)
+	}
+	if info, _ := ipam.AddrInfo("10.0.0.2"); info.Labels["rack"] != "b1" {
+		t.Fatalf("Defaults should override stored enforced labels, got %v", info.Labels)
+	}
+	// labels resolved on read are not stored, so they could not be queried
+	if entries, _ := ipam.FindAddrs("rack=b1", ""); len(entries) != 0 {
+		t.Fatalf("Labels resolved on read should not be selected, got %v", entries)
+	}
+	if report, _ := ipam.CountBy("rack", ""); report.Total.Groups["b1"] != nil || report.Total.Groups["x"].Used != 1 {
+		t.Fatal("Labels resolved on read should not be counted")
+	}
+	if err := ipam.SetQuota(&Quota{Name: "b1", Key: "rack", Value: "b1", Limit: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if used, _, err := ipam.QuotaUsage("b1"); err != nil || used != 0 {
+		t.Fatal("Labels resolved on read should not be held against quotas")
+	}
+	ipam.SetZoneDefaults("10.0.0.0/24", nil)
+	if labels, _ := ipam.AddrLabels("10.0.0.10"); labels["rack"] != "x" || len(labels) != 2 {
+		t.Fatalf("Stored labels should be returned without defaults, got %v", labels)
+	}
+	if defaults, _ := ipam.ZoneDefaults("10.0.0.0/24"); defaults != nil {
+		t.Fatal("Defaults should be cleared")
+	}
+}
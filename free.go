@@ -0,0 +1,131 @@
+package ipam
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+)
+
+// Range is a closed address interval
+type Range struct {
+	Start net.IP
+	End   net.IP
+}
+
+func (r *Range) String() string {
+	if r.Start.Equal(r.End) {
+		return r.Start.String()
+	}
+	return r.Start.String() + "-" + r.End.String()
+}
+
+// takenAddrs return used and reserved addrs of zone in loaded buckets, sorted
+func (z *zone) takenAddrs() []*big.Int {
+	result := make([]*big.Int, 0, z.UsedCount()+z.ReservedCount())
+	for addr := range z.storage.Reserved {
+		result = append(result, IPToBigInt(net.ParseIP(addr)))
+	}
+	for _, bucket := range z.storage.Buckets {
+		for addr := range bucket.GetUsed() {
+			result = append(result, IPToBigInt(net.ParseIP(addr)))
+		}
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Cmp(result[b]) < 0
+	})
+	return result
+}
+
+// FreeIntervals return the sorted and merged intervals excluding used and reserved addrs
+func (z *zone) FreeIntervals() []*interval {
+	taken := z.takenAddrs()
+	result := make([]*interval, 0)
+	appendFree := func(start, end *big.Int) {
+		if start.Cmp(end) > 0 {
+			return
+		}
+		// merge with the adjacent interval
+		if last := len(result) - 1; last >= 0 && new(big.Int).Add(result[last].end, one).Cmp(start) == 0 {
+			result[last].end = end
+			return
+		}
+		result = append(result, &interval{start: start, end: end})
+	}
+	idx := 0
+	for _, r := range z.intervals {
+		start := r.start
+		for ; idx < len(taken) && taken[idx].Cmp(r.end) <= 0; idx++ {
+			if taken[idx].Cmp(start) < 0 {
+				continue
+			}
+			appendFree(start, new(big.Int).Sub(taken[idx], one))
+			start = new(big.Int).Add(taken[idx], one)
+		}
+		appendFree(start, r.end)
+	}
+	return result
+}
+
+// largestCIDR return the largest aligned network in interval, the lowest one is chosen for ties
+func largestCIDR(r *interval, bits int) (*big.Int, int) {
+	var best *big.Int
+	bestSize := -1
+	for start := new(big.Int).Set(r.start); start.Cmp(r.end) <= 0; {
+		// the network size is limited by both alignment of start and remained length
+		size := bits
+		if start.Sign() != 0 {
+			size = int(start.TrailingZeroBits())
+		}
+		remained := new(big.Int).Sub(r.end, start)
+		remained.Add(remained, one)
+		if limit := remained.BitLen() - 1; limit < size {
+			size = limit
+		}
+		if size > bestSize {
+			best, bestSize = new(big.Int).Set(start), size
+		}
+		start.Add(start, new(big.Int).Lsh(one, uint(size)))
+	}
+	return best, bestSize
+}
+
+func (i *ipam) FreeRanges(literal string) ([]*Range, error) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.touch(zone); err != nil {
+		return nil, err
+	}
+	result := make([]*Range, 0)
+	for _, r := range zone.FreeIntervals() {
+		result = append(result, &Range{Start: BigIntToIP(r.start, zone.version), End: BigIntToIP(r.end, zone.version)})
+	}
+	return result, nil
+}
+
+func (i *ipam) LargestFreeCIDR(literal string) (*net.IPNet, error) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.touch(zone); err != nil {
+		return nil, err
+	}
+	bits := 128
+	if zone.version == 4 {
+		bits = 32
+	}
+	var best *big.Int
+	bestSize := -1
+	for _, r := range zone.FreeIntervals() {
+		if start, size := largestCIDR(r, bits); size > bestSize {
+			best, bestSize = start, size
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("No free addr in %s", zone.storage.Literal)
+	}
+	return &net.IPNet{IP: BigIntToIP(best, zone.version), Mask: net.CIDRMask(bits-bestSize, bits)}, nil
+}
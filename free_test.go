@@ -0,0 +1,54 @@
+package ipam
+
+import (
+	"testing"
+)
+
+func TestFreeRanges(t *testing.T) {
+	literal := "10.0.0.0/24,10.0.1.0-10.0.1.127"
+	ipam := New("test", nil)
+	if err := ipam.AddZone(literal, true); err != nil {
+		t.Fatal(err)
+	}
+	ipam.AllocAddrSpecific("10.0.0.1", nil)
+	ipam.AllocAddrSpecific("10.0.0.2", nil)
+	ipam.ReserveAddr("10.0.0.100", nil)
+	ipam.AllocAddrSpecific("10.0.1.64", nil)
+	ranges, err := ipam.FreeRanges(literal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"10.0.0.3-10.0.0.99", "10.0.0.101-10.0.0.254", "10.0.1.0-10.0.1.63", "10.0.1.65-10.0.1.127"}
+	if len(ranges) != len(expected) {
+		t.Fatalf("Wrong free ranges %v", ranges)
+	}
+	for idx, r := range ranges {
+		if r.String() != expected[idx] {
+			t.Fatalf("Free range %s should be %s", r, expected[idx])
+		}
+	}
+	cidr, err := ipam.LargestFreeCIDR(literal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cidr.String() != "10.0.0.128/26" {
+		t.Fatalf("Wrong largest free CIDR %s", cidr)
+	}
+
+	// merged across intervals
+	ipam.AddZone("10.0.2.1-10.0.2.5,10.0.2.6-10.0.2.9", true)
+	if ranges, _ := ipam.FreeRanges("10.0.2.1-5,10.0.2.6-9"); len(ranges) != 1 || ranges[0].String() != "10.0.2.1-10.0.2.9" {
+		t.Fatalf("Wrong free ranges %v", ranges)
+	}
+
+	ipam.AddZone("FE80::/16", true)
+	ipam.AllocAddrSpecific("FE80::1", nil)
+	if cidr, _ := ipam.LargestFreeCIDR("FE80::/16"); cidr.String() != "fe80:4000::/18" {
+		t.Fatalf("Wrong largest free CIDR %s", cidr)
+	}
+	ipam.AddZone("10.0.3.1", true)
+	ipam.AllocAddrSpecific("10.0.3.1", nil)
+	if _, err := ipam.LargestFreeCIDR("10.0.3.1"); err == nil {
+		t.Fatal("There should be no free addr")
+	}
+}
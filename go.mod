@@ -2,6 +2,9 @@ module github.com/armourstill/label-ipam
 
 go 1.18
 
-require github.com/golang/protobuf v1.5.2
+require (
+	github.com/gogo/protobuf v1.3.2
+	github.com/golang/protobuf v1.5.2
+)
 
 require google.golang.org/protobuf v1.26.0 // indirect
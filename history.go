@@ -0,0 +1,183 @@
+package ipam
+
+import (
+	"net"
+	"strings"
+)
+
+// HistoryLimit is the max count of history entries kept for an addr, 0 disables history
+var HistoryLimit = 32
+
+// ReleasedHistoryLimit is the max count of released addrs keeping their history in a zone
+var ReleasedHistoryLimit = 256
+
+// Operations recorded in history
+const (
+	HistoryAlloc   = "alloc"
+	HistoryReserve = "reserve"
+	HistoryClaim   = "claim"
+	HistoryRelease = "release"
+	HistoryLabel   = "label"
+)
+
+func copyHistoryEntry(entry *HistoryEntry) *HistoryEntry {
+	return &HistoryEntry{
+		Timestamp: entry.Timestamp,
+		Op:        entry.Op,
+		OldLabels: LabelMap(entry.OldLabels).Copy(),
+		NewLabels: LabelMap(entry.NewLabels).Copy(),
+	}
+}
+
+// labelsOf return a copy of the labels of an used or reserved ip, nil if ip is free
+func (z *zone) labelsOf(ip net.IP) LabelMap {
+	desc, _, ok := z.Locate(ip)
+	if !ok {
+		return nil
+	}
+	return LabelMap(desc.GetLabels()).Copy()
+}
+
+// historyBucketName is the last part of the key of the bucket keeping the history of released addrs of a zone
+const historyBucketName = "history"
+
+func isHistoryBucket(key string) bool {
+	return strings.HasSuffix(key, "/"+historyBucketName)
+}
+
+// releasedBucket return the key and the bucket keeping the history of released addrs of zone, the bucket is nil
+// if it is not loaded yet. If there is no such bucket and create is true, it is added
+func (z *zone) releasedBucket(prefix string, create bool) (string, *Bucket) {
+	for key, bucket := range z.storage.Buckets {
+		if isHistoryBucket(key) {
+			return key, bucket
+		}
+	}
+	if !create {
+		return "", nil
+	}
+	if z.storage.Buckets == nil {
+		z.storage.Buckets = make(map[string]*Bucket)
+	}
+	key := z.bucketPrefix(prefix) + "/" + historyBucketName
+	bucket := &Bucket{Used: make(map[string]*Descriptor), Released: make(map[string]*History)}
+	z.storage.Buckets[key] = bucket
+	return key, bucket
+}
+
+// historyOf return the history of ip, which is kept by its descriptor while ip is used or reserved, and by
+// the history bucket of zone after ip is released. If ip has no history and create is true, an empty one is
+// added. Return nil if the history bucket is not loaded yet
+func (z *zone) historyOf(prefix string, ip net.IP, create bool) *History {
+	addr := ip.String()
+	desc, ok := z.Describe(ip)
+	if ok && desc.History != nil {
+		return desc.History
+	}
+	key, bucket := z.releasedBucket(prefix, false)
+	released := bucket.GetReleased()[addr]
+	if !ok {
+		if released == nil && create {
+			if _, bucket = z.releasedBucket(prefix, true); bucket != nil {
+				if bucket.Released == nil {
+					bucket.Released = make(map[string]*History)
+				}
+				released = &History{}
+				bucket.Released[addr] = released
+			}
+		}
+		return released
+	}
+	// an addr allocated or reserved again takes back its history
+	if released != nil {
+		desc.History = released
+		delete(bucket.Released, addr)
+		if len(bucket.Released) <= 0 {
+			delete(z.storage.Buckets, key)
+		}
+	} else if create {
+		desc.History = &History{}
+	}
+	return desc.History
+}
+
+// retire moves the history of a released addr from its descriptor to the history bucket, the release is
+// recorded then
+func (z *zone) retire(prefix string, ip net.IP, desc *Descriptor) {
+	if desc.GetHistory() == nil {
+		return
+	}
+	if _, bucket := z.releasedBucket(prefix, true); bucket != nil {
+		if bucket.Released == nil {
+			bucket.Released = make(map[string]*History)
+		}
+		bucket.Released[ip.String()] = desc.History
+	}
+	desc.History = nil
+}
+
+// trimReleased drops the history of the released addrs changed least recently beyond ReleasedHistoryLimit
+func trimReleased(released map[string]*History) {
+	for len(released) > ReleasedHistoryLimit {
+		oldest, oldestTime := "", int64(0)
+		for addr, history := range released {
+			var last int64
+			if entries := history.GetEntries(); len(entries) > 0 {
+				last = entries[len(entries)-1].Timestamp
+			}
+			// the least addr is chosen on ties to keep the result deterministic
+			if oldest == "" || last < oldestTime || (last == oldestTime && addr < oldest) {
+				oldest, oldestTime = addr, last
+			}
+		}
+		delete(released, oldest)
+	}
+}
+
+// Record appends entry to the history of ip, and drops the oldest entries beyond HistoryLimit
+func (z *zone) Record(prefix string, ip net.IP, entry *HistoryEntry) {
+	if HistoryLimit <= 0 {
+		return
+	}
+	history := z.historyOf(prefix, ip, true)
+	if history == nil {
+		return
+	}
+	history.Entries = append(history.Entries, entry)
+	if over := len(history.Entries) - HistoryLimit; over > 0 {
+		history.Entries = append([]*HistoryEntry(nil), history.Entries[over:]...)
+	}
+	if _, bucket := z.releasedBucket(prefix, false); bucket != nil {
+		if _, ok := bucket.Released[ip.String()]; ok {
+			trimReleased(bucket.Released)
+		}
+	}
+}
+
+// changed keeps the holders of unique labels in sync and records op of ip which had labels old before op
+func (i *ipam) changed(zone *zone, ip net.IP, op string, old LabelMap) {
+	i.updateHolders(zone, ip, old, zone.labelsOf(ip))
+	i.record(zone, ip, op, old)
+}
+
+// record records op of ip which had labels old before op
+func (i *ipam) record(zone *zone, ip net.IP, op string, old LabelMap) {
+	zone.Record(i.prefix, ip, &HistoryEntry{
+		Timestamp: now().UnixNano(),
+		Op:        op,
+		OldLabels: old,
+		NewLabels: zone.labelsOf(ip),
+	})
+}
+
+func (i *ipam) AddrHistory(specific string) ([]*HistoryEntry, error) {
+	zone, ip, err := i.lookupAddr(specific)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*HistoryEntry, 0)
+	for _, entry := range zone.historyOf(i.prefix, ip, false).GetEntries() {
+		result = append(result, copyHistoryEntry(entry))
+	}
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package ipam
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddrHistory(t *testing.T) {
+	HistoryLimit = 4
+	ReleasedHistoryLimit = 2
+	AddrNumPerBucket = 4
+	clock := time.Unix(1600000000, 0)
+	now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	defer func() {
+		HistoryLimit = 32
+		ReleasedHistoryLimit = 256
+		AddrNumPerBucket = 4096
+		now = time.Now
+	}()
+
+	ipam1 := New("test", nil)
+	ipam1.AddZone("10.0.4.0/24", true)
+	ipam1.AllocAddrSpecific("10.0.4.17", map[string]string{"owner": "alice"})
+	ipam1.SetAddrLabel("10.0.4.17", "env", "prod")
+	ipam1.ReleaseAddr("10.0.4.17")
+	history, err := ipam1.AddrHistory("10.0.4.17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 || history[0].Op != HistoryAlloc || history[1].Op != HistoryLabel || history[2].Op != HistoryRelease {
+		t.Fatalf("Wrong history %v", history)
+	}
+	if len(history[0].OldLabels) != 0 || history[0].NewLabels["owner"] != "alice" || history[0].Timestamp >= history[1].Timestamp {
+		t.Fatalf("Wrong alloc entry %v", history[0])
+	}
+	if history[2].OldLabels["env"] != "prod" || len(history[2].NewLabels) != 0 {
+		t.Fatalf("Wrong release entry %v", history[2])
+	}
+	buckets := ipam1.(*ipam).zones["10.0.4.0/24"].storage.Buckets
+	if _, ok := buckets["test/10.0.4.0/24/history"]; len(ipam1.UsedAddrs()) != 0 || len(buckets) != 1 || !ok {
+		t.Fatalf("Empty bucket should be deleted and history bucket should be kept, got %v", buckets)
+	}
+	thin, _ := ipam1.Dump(false)
+	if strings.Contains(string(thin), "alice") {
+		t.Fatal("History should not be in thin dump")
+	}
+
+	ipam1.ReserveAddr("10.0.4.17", map[string]string{"owner": "bob"})
+	ipam1.ClaimReserved("10.0.4.17", nil)
+	history, _ = ipam1.AddrHistory("10.0.4.17")
+	if len(history) != 4 || history[0].Op != HistoryLabel || history[3].Op != HistoryClaim {
+		t.Fatalf("History should be bounded, got %v", history)
+	}
+	if history, _ := ipam1.AddrHistory("10.0.4.18"); len(history) != 0 {
+		t.Fatal("Free addr should have no history")
+	}
+	for _, addr := range []string{"10.0.4.1", "10.0.4.2", "10.0.4.3"} {
+		ipam1.AllocAddrSpecific(addr, nil)
+		ipam1.ReleaseAddr(addr)
+	}
+	if history, _ := ipam1.AddrHistory("10.0.4.1"); len(history) != 0 {
+		t.Fatal("History of the least recently released addr should be dropped")
+	}
+	if history, _ := ipam1.AddrHistory("10.0.4.3"); len(history) != 2 {
+		t.Fatalf("History of released addr should be kept, got %v", history)
+	}
+
+	raw, _ := ipam1.Dump(true)
+	ipam2 := New("test", nil)
+	ipam2.Load(raw)
+	history, _ = ipam2.AddrHistory("10.0.4.17")
+	if len(history) != 4 || history[3].NewLabels["owner"] != "bob" {
+		t.Fatalf("History should be loaded, got %v", history)
+	}
+	if _, err := ipam2.AddrHistory("10.0.5.1"); err == nil {
+		t.Fatal("Unhandled addr should have no history")
+	}
+
+	stored, _ := ipam1.DumpZoneAddrs("10.0.4.0/24", false)
+	thin, _ = ipam1.Dump(false)
+	ipam3 := New("test", nil)
+	ipam3.SetBucketLoader(BucketLoaderFunc(func(key string) ([]byte, error) {
+		return stored[key], nil
+	}))
+	ipam3.Load(thin)
+	if history, _ := ipam3.AddrHistory("10.0.4.3"); len(history) != 2 {
+		t.Fatalf("History of released addr should be paged in, got %v", history)
+	}
+}
@@ -0,0 +1,126 @@
+package ipam
+
+// labelIndex is an inverted index from label key and value to the members having the label
+type labelIndex map[string]map[string]map[string]struct{}
+
+func (idx labelIndex) add(member string, labels map[string]string) {
+	for k, v := range labels {
+		values, ok := idx[k]
+		if !ok {
+			values = make(map[string]map[string]struct{})
+			idx[k] = values
+		}
+		members, ok := values[v]
+		if !ok {
+			members = make(map[string]struct{})
+			values[v] = members
+		}
+		members[member] = struct{}{}
+	}
+}
+
+func (idx labelIndex) remove(member string, labels map[string]string) {
+	for k, v := range labels {
+		members := idx[k][v]
+		delete(members, member)
+		if len(members) > 0 {
+			continue
+		}
+		delete(idx[k], v)
+		if len(idx[k]) <= 0 {
+			delete(idx, k)
+		}
+	}
+}
+
+// count return the count of members having label key=value
+func (idx labelIndex) count(key, value string) int {
+	return len(idx[key][value])
+}
+
+// candidates return the members which could match requirement r, nil means all members could match
+func (idx labelIndex) candidates(r *Requirement) map[string]struct{} {
+	switch r.Operator {
+	case OpEquals:
+		return idx[r.Key][r.Values[0]]
+	case OpIn:
+		result := make(map[string]struct{})
+		for _, v := range r.Values {
+			for member := range idx[r.Key][v] {
+				result[member] = struct{}{}
+			}
+		}
+		return result
+	case OpExists:
+		result := make(map[string]struct{})
+		for _, members := range idx[r.Key] {
+			for member := range members {
+				result[member] = struct{}{}
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// lookup return the members which could match selector, nil means a full scan is needed
+// because selector has no requirement narrowing the members down
+func (idx labelIndex) lookup(selector Selector) map[string]struct{} {
+	var result map[string]struct{}
+	for _, r := range selector {
+		members := idx.candidates(r)
+		if members == nil {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]struct{}, len(members))
+			for member := range members {
+				result[member] = struct{}{}
+			}
+			continue
+		}
+		for member := range result {
+			if _, ok := members[member]; !ok {
+				delete(result, member)
+			}
+		}
+	}
+	return result
+}
+
+// reindex rebuilds the addr indexes of zone from the loaded buckets and reservations
+func (z *zone) reindex() {
+	z.usedIndex = make(labelIndex)
+	z.reservedIndex = make(labelIndex)
+	z.usedOrder, z.reservedOrder = nil, nil
+	for addr, desc := range z.storage.Reserved {
+		z.reservedIndex.add(addr, desc.GetLabels())
+	}
+	for _, bucket := range z.storage.Buckets {
+		z.indexBucket(bucket)
+	}
+}
+
+func (z *zone) indexBucket(bucket *Bucket) {
+	z.usedOrder = nil
+	for addr, desc := range bucket.GetUsed() {
+		z.usedIndex.add(addr, desc.GetLabels())
+	}
+}
+
+func (z *zone) unindexBucket(bucket *Bucket) {
+	z.usedOrder = nil
+	for addr, desc := range bucket.GetUsed() {
+		z.usedIndex.remove(addr, desc.GetLabels())
+	}
+}
+
+// zoneIndex return the index of zone labels in namespace, create it if not exists
+func (ins *instance) zoneIndex(namespace string) labelIndex {
+	idx, ok := ins.zoneIndexes[namespace]
+	if !ok {
+		idx = make(labelIndex)
+		ins.zoneIndexes[namespace] = idx
+	}
+	return idx
+}
@@ -0,0 +1,101 @@
+package ipam
+
+import (
+	"strconv"
+	"testing"
+)
+
+// checkIndex compares the indexes of zone with the ones rebuilt from storage
+func checkIndex(t *testing.T, z *zone) {
+	t.Helper()
+	used, reserved := z.usedIndex, z.reservedIndex
+	z.reindex()
+	for _, pair := range [][2]labelIndex{{used, z.usedIndex}, {reserved, z.reservedIndex}} {
+		if len(pair[0]) != len(pair[1]) {
+			t.Fatalf("Index %v should be %v", pair[0], pair[1])
+		}
+		for k, values := range pair[1] {
+			for v, members := range values {
+				if len(pair[0][k][v]) != len(members) {
+					t.Fatalf("Index of %s=%s should be %v, got %v", k, v, members, pair[0][k][v])
+				}
+			}
+		}
+	}
+}
+
+func TestLabelIndex(t *testing.T) {
+	ipm := New("test", nil)
+	ipm.AddZone("10.0.0.0/24", true)
+	for n := 1; n <= 20; n++ {
+		ipm.AllocAddrSpecific("10.0.0."+strconv.Itoa(n), map[string]string{"app": "app" + strconv.Itoa(n%3)})
+	}
+	ipm.AllocAddrSpecific("10.0.0.1", map[string]string{"app": "web"})
+	ipm.SetAddrLabel("10.0.0.2", "env", "prod")
+	ipm.RemoveAddrLabel("10.0.0.3", "app")
+	ipm.ReleaseAddr("10.0.0.4")
+	ipm.ReserveAddr("10.0.0.30", map[string]string{"app": "web"})
+	ipm.ReserveAddr("10.0.0.31", map[string]string{"app": "db"})
+	ipm.ClaimReserved("10.0.0.31", nil)
+	ipm.SetAddrLabel("10.0.0.30", "env", "prod")
+	z := ipm.(*ipam).zones["10.0.0.0/24"]
+	checkIndex(t, z)
+	if z.CountLabel("app", "web") != 2 || z.CountLabel("env", "prod") != 2 {
+		t.Fatal("Wrong label counts from index")
+	}
+	entries, _ := ipm.FindAddrs("app in (web,db)", "")
+	if len(entries) != 2 || entries[0].IP.String() != "10.0.0.1" || entries[1].IP.String() != "10.0.0.31" {
+		t.Fatalf("Wrong found addrs %v", entries)
+	}
+
+	ipm.SetZoneLabel("10.0.0.0/24", "site", "fra")
+	ipm.AddZone("10.0.1.0/24", true)
+	ipm.SetZoneLabel("10.0.1.0/24", "site", "ams")
+	ipm.RemoveZoneLabel("10.0.1.0/24", "site")
+	zones, _ := ipm.(*ipam).selectZones("site")
+	if len(zones) != 1 || zones[0].storage.Literal != "10.0.0.0/24" {
+		t.Fatal("Wrong zones from index")
+	}
+	ipm.RemoveZone("10.0.0.0/24")
+	if idx := ipm.(*ipam).zoneIndex(DefaultNamespace); len(idx) != 0 {
+		t.Fatalf("Zone index should be empty, got %v", idx)
+	}
+}
+
+func TestLabelIndexLoad(t *testing.T) {
+	ipam1 := New("test", nil)
+	ipam1.AddZone("10.0.0.0/24", true)
+	ipam1.SetZoneLabel("10.0.0.0/24", "site", "fra")
+	ipam1.AllocAddrSpecific("10.0.0.1", map[string]string{"app": "web"})
+	ipam1.ReserveAddr("10.0.0.2", map[string]string{"app": "web"})
+	raw, _ := ipam1.Dump(false)
+	addrs, _ := ipam1.DumpZoneAddrs("10.0.0.0/24", false)
+
+	ipam2 := New("test", nil)
+	ipam2.SetBucketLoader(BucketLoaderFunc(func(key string) ([]byte, error) {
+		return addrs[key], nil
+	}))
+	if err := ipam2.Load(raw); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ipam2.FindAddrs("app=web", "site=fra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].IP.String() != "10.0.0.1" {
+		t.Fatalf("Paged in addrs should be indexed, got %v", entries)
+	}
+	if entries, _ := ipam2.FindReserved("app=web", ""); len(entries) != 1 {
+		t.Fatalf("Loaded reservations should be indexed, got %v", entries)
+	}
+
+	ipam3 := New("test", nil)
+	ipam3.Load(raw)
+	if err := ipam3.LoadZoneAddrs("10.0.0.0/24", addrs, false); err != nil {
+		t.Fatal(err)
+	}
+	checkIndex(t, ipam3.(*ipam).zones["10.0.0.0/24"])
+	if entries, _ := ipam3.FindAddrs("app=web", ""); len(entries) != 1 {
+		t.Fatalf("Loaded addrs should be indexed, got %v", entries)
+	}
+}
@@ -0,0 +1,91 @@
+package ipam
+
+import (
+	"fmt"
+	"net"
+)
+
+// AddrState is the state of an addr in an IPAM
+type AddrState int
+
+const (
+	// The addr is not in any zone
+	AddrUnmanaged AddrState = iota
+	// The addr could be allocated or reserved
+	AddrFree
+	AddrUsed
+	AddrReserved
+	// The addr is in the network of a CIDR zone but unavailable, such as the broadcast address
+	AddrExcluded
+	// The addr is held back from allocation after being released.
+	// Addrs are released to free immediately for now, so AddrInfo never reports this state
+	AddrQuarantined
+)
+
+func (s AddrState) String() string {
+	switch s {
+	case AddrUnmanaged:
+		return "unmanaged"
+	case AddrFree:
+		return "free"
+	case AddrUsed:
+		return "used"
+	case AddrReserved:
+		return "reserved"
+	case AddrExcluded:
+		return "excluded"
+	case AddrQuarantined:
+		return "quarantined"
+	}
+	return fmt.Sprintf("AddrState(%d)", int(s))
+}
+
+// AddrInfo describes an addr
+type AddrInfo struct {
+	IP        net.IP
+	State     AddrState
+	Namespace string
+	// Literal of the zone owning the addr, empty if unmanaged
+	Literal  string
+	RefCount uint32
+	Revision uint64
+	Labels   LabelMap
+	// Key of the bucket holding the addr, empty if it is not used
+	BucketKey string
+}
+
+func (i *ipam) AddrInfo(specific string) (*AddrInfo, error) {
+	ip := net.ParseIP(specific)
+	if ip == nil {
+		return nil, fmt.Errorf("Invalid IP format %s", specific)
+	}
+	info := &AddrInfo{IP: ip, State: AddrUnmanaged, Namespace: i.namespace}
+	zone := i.zoneOf(ip)
+	if zone == nil {
+		for _, z := range i.zones {
+			if z.Excludes(ip) {
+				info.State, info.Literal = AddrExcluded, z.storage.Literal
+				break
+			}
+		}
+		return info, nil
+	}
+	if err := i.touch(zone); err != nil {
+		return nil, err
+	}
+	info.State, info.Literal = AddrFree, zone.storage.Literal
+	desc, key, ok := zone.Locate(ip)
+	if !ok {
+		return info, nil
+	}
+	if zone.IPReserved(ip) {
+		info.State = AddrReserved
+	} else {
+		info.State = AddrUsed
+	}
+	info.RefCount = desc.GetRefCount()
+	info.Revision = desc.GetRevision()
+	info.Labels = zone.resolve(desc.GetLabels())
+	info.BucketKey = key
+	return info, nil
+}
@@ -0,0 +1,49 @@
+package ipam
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddrInfo(t *testing.T) {
+	literal := "10.1.2.0/24"
+	ipam := New("test", nil)
+	ipam.AddZone(literal, true)
+	ipam.AllocAddrSpecific("10.1.2.3", map[string]string{"app": "web"})
+	ipam.AllocAddrSpecific("10.1.2.3", nil)
+	ipam.ReserveAddr("10.1.2.4", map[string]string{"app": "db"})
+
+	cases := map[string]AddrState{
+		"10.1.2.3":   AddrUsed,
+		"10.1.2.4":   AddrReserved,
+		"10.1.2.5":   AddrFree,
+		"10.1.2.255": AddrExcluded,
+		"10.1.3.1":   AddrUnmanaged,
+	}
+	for addr, state := range cases {
+		info, err := ipam.AddrInfo(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.State != state {
+			t.Fatalf("State of %s should be %s, got %s", addr, state, info.State)
+		}
+		if state != AddrUnmanaged && info.Literal != literal {
+			t.Fatalf("Wrong literal of %s", addr)
+		}
+	}
+	info, _ := ipam.AddrInfo("10.1.2.3")
+	if info.RefCount != 2 || info.Labels["app"] != "web" || !strings.HasPrefix(info.BucketKey, "test/"+literal+"/") {
+		t.Fatalf("Wrong info of used addr %v", info)
+	}
+	info, _ = ipam.AddrInfo("10.1.2.4")
+	if info.Labels["app"] != "db" || info.BucketKey != "" {
+		t.Fatalf("Wrong info of reserved addr %v", info)
+	}
+	if _, err := ipam.AddrInfo("10.1.2"); err == nil {
+		t.Fatal("Addr should be invalid")
+	}
+	if AddrQuarantined.String() != "quarantined" || AddrState(-1).String() != "AddrState(-1)" {
+		t.Fatal("Wrong state names")
+	}
+}
@@ -6,12 +6,24 @@ import (
 	"net"
 )
 
+// DefaultNamespace is the namespace of IPAM returned by New
+const DefaultNamespace = ""
+
 // IPAM manage IPv4 and IPv6 addresses and serialize them
+//
+// Zones and addrs are scoped to a namespace (or VRF), zones in different namespaces could overlap.
+// Labels, settings, Dump and Load are shared by all namespaces of an IPAM.
 type IPAM interface {
+	// Return the view of IPAM scoped to namespace name, it is created on first use
+	Namespace(name string) IPAM
+	// Return the namespace of this view
+	CurrentNamespace() string
+	// List all namespaces which have zones
+	Namespaces() []string
 	// Set label of IPAM itself
-	SetLabel(key, value string)
+	SetLabel(key, value string) error
 	// Remove label of IPAM, return the value and the key exists or not
-	RemoveLabel(key string) (string, bool)
+	RemoveLabel(key string) (string, bool, error)
 	// List all labels
 	Labels() LabelMap
 	// Add address segment, or called zone
@@ -24,55 +36,197 @@ type IPAM interface {
 	//
 	// 3. CIDR network address, such as 192.168.0..0/24 or FE80::/64
 	//
+	// 4. Network address with netmask, such as 192.168.0.0/255.255.255.0
+	//
+	// 5. Short interval replacing the trailing parts of the left IP, such as 192.168.1.10-20 or FE80::1:10-20
+	//
+	// 6. Comma joined list of disjoint formats above, such as 192.168.1.0/24,192.168.3.10-20
+	// Touching single IPs and intervals of a list are merged, so 192.168.3.10-15,192.168.3.16-20 is 192.168.3.10-20
+	//
 	// Warning: the zero address and broadcast address will be unavailable when using a CIDR network address.
 	// If they must be used, please change format to interval.
 	//
-	// Field lazy is invalid for now
+	// Every method taking a zone literal accepts any equivalent spelling of it, see CanonicalLiteral.
+	//
+	// If lazy is true, the buckets of zone which are not loaded yet will be fetched through the BucketLoader
+	// on first access, so that only the thin dump of zone needs to be loaded at the beginning
 	AddZone(literal string, lazy bool) error
 	// Set label of zone
 	SetZoneLabel(literal, key, value string) error
 	// Remove a zone
 	RemoveZone(literal string) error
 	// Remove label of zone, return the value and the key exists or not
-	RemoveZoneLabel(literal, key string) (string, bool)
+	RemoveZoneLabel(literal, key string) (string, bool, error)
 	// List all labels of a zone
 	ZoneLabels(literal string) (LabelMap, bool)
+	// Set annotation of zone, annotations hold large data which is not used by queries
+	SetZoneAnnotation(literal, key, value string) error
+	// Remove annotation of zone, return the value and the key exists or not
+	RemoveZoneAnnotation(literal, key string) (string, bool)
+	// List all annotations of a zone
+	ZoneAnnotations(literal string) (LabelMap, bool)
+	// Set network settings of a zone after validation, nil config clears them
+	SetZoneNetworkConfig(literal string, config *NetworkConfig) error
+	// Return a copy of network settings of a zone, nil if not set
+	ZoneNetworkConfig(literal string) (*NetworkConfig, error)
+	// Set default labels of the addrs allocated or reserved in a zone, nil defaults clears them.
+	// Values given by callers win unless their keys are enforced. If defaults are resolved on read,
+	// inherited labels are added by AddrLabels and AddrInfo instead of being stored, so changes of
+	// defaults propagate. Inherited labels could not be queried then: FindAddrs, FindReserved, CountBy,
+	// quotas, unique constraints and label policies only see the stored labels
+	SetZoneDefaults(literal string, defaults *ZoneDefaults) error
+	// Return a copy of default labels of a zone, nil if not set
+	ZoneDefaults(literal string) (*ZoneDefaults, error)
+	// Set allocation priority and weight of a zone, see AllocStrategy
+	SetZonePriority(literal string, priority int32, weight uint32) error
+	// Return allocation priority and weight of a zone
+	ZonePriority(literal string) (int32, uint32, error)
+	// Set the strategy of choosing zones for AllocAddrNext, default is AllocByPriority
+	SetAllocStrategy(strategy AllocStrategy)
+	// Add or replace a quota by name in this namespace, the quota limits how many used and reserved addrs
+	// could hold its label in the limited zones. Allocations and reservations exceeding a quota fail with
+	// an error wrapping ErrQuotaExceeded, AllocAddrNext tries other zones at first.
+	SetQuota(quota *Quota) error
+	// Remove a quota, return the quota exists or not
+	RemoveQuota(name string) bool
+	// List all quotas of this namespace ordered by name
+	Quotas() []*Quota
+	// Return the current usage and limit of a quota
+	QuotaUsage(name string) (uint64, uint64, error)
+	// Add or replace a unique constraint of label key in this namespace, the label value could be held by at most
+	// one used or reserved addr in the scope, which is UniqueInZone, UniqueInNamespace or UniqueInIPAM.
+	// Labels violating it fail with an error wrapping ErrLabelConflict which names the holding addr
+	SetUniqueLabel(key string, scope int32) error
+	// Remove a unique constraint of this namespace, or one of UniqueInIPAM scope defined in any namespace,
+	// return the constraint exists or not
+	RemoveUniqueLabel(key string) bool
+	// List all unique constraints of this namespace ordered by key
+	UniqueLabels() []*UniqueLabel
+	// Return the used or reserved addrs holding a unique label in the scope of its constraint,
+	// there is more than one only if the scope is UniqueInZone. Constraints of UniqueInIPAM scope are found from
+	// any namespace
+	LookupByUniqueLabel(key, value string) ([]*AddrEntry, error)
+	// Set default utilization thresholds in percentage for the zones of all namespaces without their own
+	SetThresholds(thresholds ...float64) error
+	// Set utilization thresholds in percentage of a zone, empty thresholds means using the default ones
+	SetZoneThresholds(literal string, thresholds ...float64) error
+	// Set the handler called when the utilization of a zone crosses a threshold in either direction
+	// by allocation, reservation or release
+	OnThreshold(handler ThresholdHandler)
 	// Return available address count as a string, the value is 'all - used - reserved'
 	IdleCount() string
-	// Return all used addresses
+	// Return the statistics of a zone
+	ZoneStats(literal string) (*ZoneStats, error)
+	// Return the statistics of all zones, broken down by zone and address family
+	Stats() (*Stats, error)
+	// Count used and reserved addrs grouped by the value of label key, in the zones whose labels match
+	// zoneSelector and in total. Addrs without the label are counted as missing
+	CountBy(key, zoneSelector string) (*GroupReport, error)
+	// Return the sorted and merged free intervals of a zone, excluding used and reserved addrs
+	FreeRanges(literal string) ([]*Range, error)
+	// Return the largest aligned network whose addrs are all free in a zone
+	LargestFreeCIDR(literal string) (*net.IPNet, error)
+	// Return all used addresses ordered by address, IPv4 before IPv6
 	UsedAddrs() []string
-	// Return all reserved addresses
+	// Return all reserved addresses ordered by address, IPv4 before IPv6
 	ReservedAddrs() []string
+	// Call fn for used addrs limited by opts in address order until fn returns false
+	RangeUsed(opts *RangeOptions, fn func(entry *AddrEntry) bool) error
+	// Call fn for reserved addrs limited by opts in address order until fn returns false
+	RangeReserved(opts *RangeOptions, fn func(entry *AddrEntry) bool) error
+	// Return at most limit used addrs after cursor in address order, and the cursor of next page.
+	// Empty cursor means the beginning, and the returned cursor is empty at the end
+	ListUsed(cursor string, limit int, opts *RangeOptions) ([]*AddrEntry, string, error)
+	// Same as ListUsed, but for reserved addrs
+	ListReserved(cursor string, limit int, opts *RangeOptions) ([]*AddrEntry, string, error)
+	// Return the zones whose labels match selector ordered by address, with their labels and statistics.
+	// The address family of a zone is in its statistics
+	FindZones(selector string) ([]*ZoneEntry, error)
+	// Return used addrs whose labels match selector in the zones whose labels match zoneSelector, ordered by address.
+	// Selectors use the syntax of ParseSelector, empty selector matches everything
+	FindAddrs(selector, zoneSelector string) ([]*AddrEntry, error)
+	// Same as FindAddrs, but for reserved addrs
+	FindReserved(selector, zoneSelector string) ([]*AddrEntry, error)
 	// Allocate a specified addr and add/update it's labels, an used addr can be allocated again
 	AllocAddrSpecific(specific string, labels LabelMap) error
-	// Allocate a new random addr and add it's labels
+	// Allocate a new addr and add it's labels, zones are chosen by AllocStrategy and the lowest free addr is used
 	AllocAddrNext(labels LabelMap) (net.IP, error)
+	// Same as AllocAddrSpecific, but also return the network settings of the zone
+	AllocAddrSpecificWithConfig(specific string, labels LabelMap) (*Allocation, error)
+	// Same as AllocAddrNext, but also return the network settings of the zone
+	AllocAddrNextWithConfig(labels LabelMap) (*Allocation, error)
 	// Reserve an unused addr and add it's labels
 	ReserveAddr(specific string, labels LabelMap) error
+	// Turn a reserved addr into an used one atomically, labels are merged into the labels of reservation
+	ClaimReserved(specific string, labels LabelMap) error
 	// Release an used or reserved addr, some used addrs could be released more than one time
 	ReleaseAddr(specific string) error
+	// Set annotation of an used or reserved addr, annotations hold large data which is not used by queries
+	SetAddrAnnotation(specific, key, value string) error
+	// Remove annotation of an used or reserved addr
+	RemoveAddrAnnotation(specific, key string) error
+	// List all annotations of an used or reserved addr
+	AddrAnnotations(specific string) (LabelMap, error)
+	// Set the policy validating labels of IPAM, zones and addrs, nil means all labels are accepted.
+	// Existing labels are not validated again
+	SetLabelPolicy(policy LabelPolicy)
+	// Set the policy validating labels of a zone and its addrs, it is applied after the policy of IPAM.
+	// Zone policies are not persisted by Dump, a reloaded zone keeps the policy of the zone it replaces
+	SetZoneLabelPolicy(literal string, policy LabelPolicy) error
 	// Set label of an used or reserved addr
 	SetAddrLabel(specific, key, value string) error
 	// Remove label of an used or reserved addr
 	RemoveAddrLabel(specific, key string) error
+	// Update labels of an used or reserved addr atomically by mode, and return the new revision of its descriptor.
+	// If expectedRevision is not 0 and the descriptor is at another revision, nothing is changed and
+	// an error wrapping ErrRevisionConflict is returned
+	UpdateAddrLabels(specific string, set LabelMap, remove []string, expectedRevision uint64,
+		mode UpdateMode) (uint64, error)
 	// List all labels of an used or reserved addr
 	AddrLabels(specific string) (LabelMap, error)
+	// Return the recent allocations, reservations, releases and label changes of an addr, the oldest first.
+	// At most HistoryLimit entries are kept for an addr with its descriptor, and the history of at most
+	// ReleasedHistoryLimit released addrs is kept by the history bucket of a zone, which is paged in as other buckets
+	AddrHistory(specific string) ([]*HistoryEntry, error)
+	// Return the state, owning zone and descriptor of any addr, whether it is managed or not
+	AddrInfo(specific string) (*AddrInfo, error)
 	// Find the zone literal from an addr
 	FindLiteral(specific string) string
-	// List all zone literals
+	// List all zone literals ordered by address, IPv4 before IPv6
 	Literals() []string
-	// Export all zones with allocated addrs as bytes
+	// Export all zones of all namespaces with allocated addrs as bytes
 	//
-	// If fat is true, then descriptor info contained
+	// If fat is true, then descriptor info contained. Identical state is always dumped into identical bytes
 	Dump(fat bool) ([]byte, error)
+	// Same as Dump, but decided by opts, nil opts is the same as Dump(false)
+	DumpWithOptions(opts *DumpOptions) ([]byte, error)
 	// Export specified zone with allocated addrs as bytes
 	//
 	// If onlyKeys is true, then omits any descriptor info
 	DumpZoneAddrs(literal string, onlyKeys bool) (map[string][]byte, error)
-	// Load all zones from bytes, cover the zone with same literal
+	// Set the loader fetching buckets of lazy zones
+	SetBucketLoader(loader BucketLoader)
+	// Page in the buckets of all lazy zones in this namespace, return the error if a bucket could not be loaded.
+	// IdleCount, UsedAddrs and ReservedAddrs skip the zones failed to be paged in, call PageIn to detect them
+	PageIn() error
+	// Load labels and all zones from bytes into their namespaces, cover the label and zone with same key
+	//
+	// Buckets of lazy zones could be omitted by a thin dump, they will be fetched through the BucketLoader
 	Load(raw []byte) error
 	// Load specified zone from bytes. If a key in addrs is not contained in zone, then it will be ignored.
 	//
 	// If force is true, then load all keys in addrs
 	LoadZoneAddrs(literal string, addrs map[string][]byte, force bool) error
 }
+
+// BucketLoader fetches a bucket exported by DumpZoneAddrs, key is the bucket key such as prefix/literal/index
+type BucketLoader interface {
+	LoadBucket(key string) ([]byte, error)
+}
+
+// BucketLoaderFunc is an adapter to use an ordinary function as BucketLoader
+type BucketLoaderFunc func(key string) ([]byte, error)
+
+func (f BucketLoaderFunc) LoadBucket(key string) ([]byte, error) {
+	return f(key)
+}
@@ -0,0 +1,210 @@
+package ipam
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+)
+
+// AddrEntry is an used or reserved addr with a copy of its descriptor
+type AddrEntry struct {
+	IP         net.IP
+	Namespace  string
+	Literal    string
+	Descriptor *Descriptor
+}
+
+// RangeOptions limits the addrs walked by iterators, nil options means no limit
+type RangeOptions struct {
+	// Literal of the only zone to walk, empty means all zones
+	Literal string
+	// 4 or 6 to walk only one address family, 0 means both
+	Version uint8
+}
+
+type addrDesc struct {
+	addr *big.Int
+	desc *Descriptor
+}
+
+func copyDescriptor(desc *Descriptor) *Descriptor {
+	if desc == nil {
+		return &Descriptor{}
+	}
+	return &Descriptor{
+		Labels:      LabelMap(desc.Labels).Copy(),
+		RefCount:    desc.RefCount,
+		Revision:    desc.Revision,
+		Annotations: LabelMap(desc.Annotations).Copy(),
+	}
+}
+
+// sortedDescs return addrs of descs after the addr after (nil means from the beginning) ordered by address
+func sortedDescs(after *big.Int, descs ...map[string]*Descriptor) []*addrDesc {
+	result := make([]*addrDesc, 0)
+	for _, m := range descs {
+		for addr, desc := range m {
+			n := IPToBigInt(net.ParseIP(addr))
+			if after != nil && n.Cmp(after) <= 0 {
+				continue
+			}
+			result = append(result, &addrDesc{addr: n, desc: desc})
+		}
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].addr.Cmp(result[b].addr) < 0
+	})
+	return result
+}
+
+// order return the sorted used or reserved addrs in loaded buckets, it is built if not yet
+func (z *zone) order(reserved bool) *addrOrder {
+	if reserved {
+		if z.reservedOrder == nil {
+			z.reservedOrder = newAddrOrder(z.storage.Reserved)
+		}
+		return z.reservedOrder
+	}
+	if z.usedOrder == nil {
+		used := make([]map[string]*Descriptor, 0, len(z.storage.Buckets))
+		for _, bucket := range z.storage.Buckets {
+			used = append(used, bucket.GetUsed())
+		}
+		z.usedOrder = newAddrOrder(used...)
+	}
+	return z.usedOrder
+}
+
+// Walk calls fn for the used or reserved addrs in loaded buckets after the addr after in address order,
+// return false if fn stops walking
+func (z *zone) Walk(reserved bool, after *big.Int, fn func(entry *AddrEntry) bool) bool {
+	// seek by addr on every step, fn may change the order
+	for n := z.order(reserved).after(after); n != nil; n = z.order(reserved).after(n) {
+		ip := BigIntToIP(n, z.version)
+		var desc *Descriptor
+		if reserved {
+			desc = z.storage.Reserved[ip.String()]
+		} else {
+			desc, _ = z.GetAddrDesc(ip)
+		}
+		if !fn(z.entry(n, desc)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (z *zone) entry(addr *big.Int, desc *Descriptor) *AddrEntry {
+	return &AddrEntry{
+		IP:         BigIntToIP(addr, z.version),
+		Namespace:  z.storage.Namespace,
+		Literal:    z.storage.Literal,
+		Descriptor: copyDescriptor(desc),
+	}
+}
+
+// emit calls fn with the entries of descs until fn returns false
+func (z *zone) emit(descs []*addrDesc, fn func(entry *AddrEntry) bool) bool {
+	for _, d := range descs {
+		if !fn(z.entry(d.addr, d.desc)) {
+			return false
+		}
+	}
+	return true
+}
+
+// last return the last addr of zone
+func (z *zone) last() *big.Int {
+	return z.intervals[len(z.intervals)-1].end
+}
+
+// walk walks the zones limited by opts in address order, starting after the cursor addr
+func (i *ipam) walk(reserved bool, opts *RangeOptions, cursor net.IP, fn func(entry *AddrEntry) bool) error {
+	if opts == nil {
+		opts = &RangeOptions{}
+	}
+	var zones []*zone
+	if opts.Literal != "" {
+		z, err := i.getZone(opts.Literal)
+		if err != nil {
+			return err
+		}
+		zones = []*zone{z}
+	} else {
+		zones = sortedZones(i.zones)
+	}
+	for _, zone := range zones {
+		if opts.Version != 0 && opts.Version != zone.version {
+			continue
+		}
+		var after *big.Int
+		if cursor != nil {
+			cursorVersion := ipVersion(cursor)
+			if cursorVersion > zone.version {
+				continue
+			}
+			if cursorVersion == zone.version {
+				after = IPToBigInt(cursor)
+				// the zone is walked already
+				if zone.last().Cmp(after) <= 0 {
+					continue
+				}
+			}
+		}
+		if err := i.touch(zone); err != nil {
+			return err
+		}
+		if !zone.Walk(reserved, after, fn) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (i *ipam) RangeUsed(opts *RangeOptions, fn func(entry *AddrEntry) bool) error {
+	return i.walk(false, opts, nil, fn)
+}
+
+func (i *ipam) RangeReserved(opts *RangeOptions, fn func(entry *AddrEntry) bool) error {
+	return i.walk(true, opts, nil, fn)
+}
+
+// list return at most limit entries after cursor, and the cursor of next page which is empty at the end
+func (i *ipam) list(reserved bool, cursor string, limit int, opts *RangeOptions) ([]*AddrEntry, string, error) {
+	var after net.IP
+	if cursor != "" {
+		if after = net.ParseIP(cursor); after == nil {
+			return nil, "", fmt.Errorf("Invalid cursor %s", cursor)
+		}
+	}
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("Invalid limit %d", limit)
+	}
+	result := make([]*AddrEntry, 0, limit)
+	more := false
+	err := i.walk(reserved, opts, after, func(entry *AddrEntry) bool {
+		if len(result) >= limit {
+			more = true
+			return false
+		}
+		result = append(result, entry)
+		return true
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if more {
+		next = result[len(result)-1].IP.String()
+	}
+	return result, next, nil
+}
+
+func (i *ipam) ListUsed(cursor string, limit int, opts *RangeOptions) ([]*AddrEntry, string, error) {
+	return i.list(false, cursor, limit, opts)
+}
+
+func (i *ipam) ListReserved(cursor string, limit int, opts *RangeOptions) ([]*AddrEntry, string, error) {
+	return i.list(true, cursor, limit, opts)
+}
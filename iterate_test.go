@@ -0,0 +1,74 @@
+package ipam
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestIterate(t *testing.T) {
+	ipam := New("test", nil)
+	ipam.AddZone("FE80::/64", true)
+	ipam.AddZone("10.0.1.0/24", true)
+	ipam.AddZone("10.0.0.0/24", true)
+	for _, n := range []int{10, 2, 1, 100} {
+		ipam.AllocAddrSpecific("10.0.0."+strconv.Itoa(n), map[string]string{"n": strconv.Itoa(n)})
+		ipam.AllocAddrSpecific("10.0.1."+strconv.Itoa(n), nil)
+	}
+	ipam.AllocAddrSpecific("FE80::1", nil)
+	ipam.ReserveAddr("10.0.0.3", nil)
+
+	expected := []string{"10.0.0.1", "10.0.0.2", "10.0.0.10", "10.0.0.100", "10.0.1.1", "10.0.1.2", "10.0.1.10",
+		"10.0.1.100", "fe80::1"}
+	cursor, walked := "", make([]string, 0)
+	for {
+		entries, next, err := ipam.ListUsed(cursor, 4, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			walked = append(walked, entry.IP.String())
+		}
+		if cursor = next; cursor == "" {
+			break
+		}
+	}
+	if len(walked) != len(expected) {
+		t.Fatalf("Wrong walked addrs %v", walked)
+	}
+	for idx, addr := range walked {
+		if addr != expected[idx] {
+			t.Fatalf("Walked addrs should be ordered, got %v", walked)
+		}
+	}
+
+	count := 0
+	ipam.RangeUsed(&RangeOptions{Literal: "10.0.0.0/255.255.255.0"}, func(entry *AddrEntry) bool {
+		if entry.Descriptor.Labels["n"] != strconv.Itoa(int(entry.IP.To4()[3])) {
+			t.Fatalf("Wrong descriptor of %s", entry.IP)
+		}
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Fatal("Walking should be stopped")
+	}
+	if entries, _, _ := ipam.ListUsed("", 10, &RangeOptions{Version: 6}); len(entries) != 1 {
+		t.Fatal("Only 1 IPv6 addr should be listed")
+	}
+	if entries, next, _ := ipam.ListReserved("", 10, nil); len(entries) != 1 || next != "" {
+		t.Fatal("Only 1 reserved addr should be listed")
+	}
+
+	// changes between pages and during walking are seen by the following pages
+	_, next, _ := ipam.ListUsed("", 2, nil)
+	ipam.AllocAddrSpecific("10.0.0.5", nil)
+	ipam.ReleaseAddr("10.0.0.10")
+	ipam.RangeUsed(&RangeOptions{Literal: "10.0.1.0/24"}, func(entry *AddrEntry) bool {
+		ipam.ReleaseAddr(entry.IP.String())
+		return true
+	})
+	entries, next, _ := ipam.ListUsed(next, 10, nil)
+	if len(entries) != 3 || entries[0].IP.String() != "10.0.0.5" || entries[1].IP.String() != "10.0.0.100" || next != "" {
+		t.Fatalf("Wrong entries after changes %v", entries)
+	}
+}
@@ -1,5 +1,10 @@
 package ipam
 
+import "errors"
+
+// ErrRevisionConflict is wrapped by the errors of label updates expecting another revision
+var ErrRevisionConflict = errors.New("Revision conflict")
+
 type LabelMap map[string]string
 
 func (lm LabelMap) Copy() LabelMap {
@@ -9,3 +14,23 @@ func (lm LabelMap) Copy() LabelMap {
 	}
 	return m
 }
+
+// Includes return true if lm has all labels of subset
+func (lm LabelMap) Includes(subset LabelMap) bool {
+	for k, v := range subset {
+		if value, ok := lm[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateMode decides how UpdateAddrLabels changes the labels of an addr
+type UpdateMode int
+
+const (
+	// Labels to set are added or overwritten, and labels to remove are removed
+	UpdateMerge UpdateMode = iota
+	// Labels are replaced by the labels to set, and labels to remove are ignored
+	UpdateReplace
+)
@@ -0,0 +1,250 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// interval is a closed address interval [start, end]
+type interval struct {
+	start *big.Int
+	end   *big.Int
+}
+
+func (r *interval) Contains(ipBigInt *big.Int) bool {
+	return r.start.Cmp(ipBigInt) <= 0 && r.end.Cmp(ipBigInt) >= 0
+}
+
+func (r *interval) Overlapped(other *interval) bool {
+	return r.start.Cmp(other.end) <= 0 && r.end.Cmp(other.start) >= 0
+}
+
+// Size return the address count of interval
+func (r *interval) Size() *big.Int {
+	size := new(big.Int).Sub(r.end, r.start)
+	return size.Add(size, one)
+}
+
+// zoneLiteral is the parsed form of a zone literal
+type zoneLiteral struct {
+	canonical string
+	version   uint8
+	// Sorted and disjoint
+	intervals []*interval
+	// Zero addresses and broadcast addresses of CIDR networks
+	excluded []*big.Int
+}
+
+// CanonicalLiteral return the canonical form of a zone literal, equivalent spellings have the same canonical form
+func CanonicalLiteral(literal string) (string, error) {
+	lit, err := parseLiteral(literal)
+	if err != nil {
+		return "", err
+	}
+	return lit.canonical, nil
+}
+
+func ipVersion(ip net.IP) uint8 {
+	if IsIPv4(ip) {
+		return 4
+	}
+	return 6
+}
+
+// parseLiteral parses a comma joined list of literal parts, each part is one of the formats accepted by AddZone
+func parseLiteral(literal string) (*zoneLiteral, error) {
+	parts := strings.Split(literal, ",")
+	lits := make([]*zoneLiteral, 0, len(parts))
+	for _, part := range parts {
+		lit, err := parseLiteralPart(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		lits = append(lits, lit)
+	}
+	if len(lits) == 1 {
+		return lits[0], nil
+	}
+	sort.Slice(lits, func(a, b int) bool {
+		return lits[a].intervals[0].start.Cmp(lits[b].intervals[0].start) < 0
+	})
+	merged := make([]*zoneLiteral, 0, len(lits))
+	for idx, lit := range lits {
+		if lit.version != lits[0].version {
+			return nil, errors.New("Invalid literal list: IPs format are different")
+		}
+		if idx > 0 && lits[idx-1].intervals[0].Overlapped(lit.intervals[0]) {
+			return nil, fmt.Errorf("Invalid literal list: %s overlapped with %s", lits[idx-1].canonical, lit.canonical)
+		}
+		// touching parts are merged to have the canonical form of their union, except CIDR networks whose
+		// excluded addrs make them different from their union
+		if last := len(merged) - 1; last >= 0 && len(merged[last].excluded) == 0 && len(lit.excluded) == 0 &&
+			big.NewInt(0).Add(merged[last].intervals[0].end, one).Cmp(lit.intervals[0].start) == 0 {
+			merged[last] = rangeLiteral(merged[last].intervals[0].start, lit.intervals[0].end, lit.version)
+			continue
+		}
+		merged = append(merged, lit)
+	}
+	if len(merged) == 1 {
+		return merged[0], nil
+	}
+	result := &zoneLiteral{version: merged[0].version}
+	canonicals := make([]string, 0, len(merged))
+	for _, lit := range merged {
+		canonicals = append(canonicals, lit.canonical)
+		result.intervals = append(result.intervals, lit.intervals[0])
+		result.excluded = append(result.excluded, lit.excluded...)
+	}
+	result.canonical = strings.Join(canonicals, ",")
+	return result, nil
+}
+
+// rangeLiteral return the literal of interval from start to end
+func rangeLiteral(start, end *big.Int, version uint8) *zoneLiteral {
+	return &zoneLiteral{
+		canonical: BigIntToIP(start, version).String() + "-" + BigIntToIP(end, version).String(),
+		version:   version,
+		intervals: []*interval{{start: start, end: end}},
+	}
+}
+
+func parseLiteralPart(literal string) (*zoneLiteral, error) {
+	if single := net.ParseIP(literal); single != nil {
+		ipBigInt := IPToBigInt(single)
+		return &zoneLiteral{
+			canonical: single.String(),
+			version:   ipVersion(single),
+			intervals: []*interval{{start: ipBigInt, end: ipBigInt}},
+		}, nil
+	} else if pair := strings.Split(literal, "/"); len(pair) == 2 {
+		ipnet, err := parseNetwork(strings.TrimSpace(pair[0]), strings.TrimSpace(pair[1]))
+		if err != nil {
+			return nil, err
+		}
+		return parseCIDR(ipnet), nil
+	} else if pair := strings.Split(literal, "-"); len(pair) == 2 {
+		low := net.ParseIP(strings.TrimSpace(pair[0]))
+		if low == nil {
+			return nil, errors.New("Invalid IP range value")
+		}
+		high := parseRangeHigh(low, strings.TrimSpace(pair[1]))
+		if high == nil {
+			return nil, errors.New("Invalid IP range value")
+		}
+		if (IsIPv4(low) && !IsIPv4(high)) || (IsIPv6(low) && !IsIPv6(high)) {
+			return nil, errors.New("Invalid IP range value: IPs format are different")
+		}
+		start, end := IPToBigInt(low), IPToBigInt(high)
+		if start.Cmp(end) >= 0 {
+			return nil, errors.New("The left IP should be less than the right one")
+		}
+		return &zoneLiteral{
+			canonical: low.String() + "-" + high.String(),
+			version:   ipVersion(low),
+			intervals: []*interval{{start: start, end: end}},
+		}, nil
+	}
+	return nil, errors.New("Invalid format")
+}
+
+// parseNetwork accepts both prefix length (192.168.0.0/24) and netmask (192.168.0.0/255.255.255.0) notation
+func parseNetwork(addr, mask string) (*net.IPNet, error) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil, errors.New("Invalid CIDR network value")
+	}
+	bits := 128
+	if IsIPv4(ip) {
+		ip, bits = ip.To4(), 32
+	}
+	var ipMask net.IPMask
+	if ones, err := strconv.Atoi(mask); err == nil {
+		if ones < 0 || ones > bits {
+			return nil, errors.New("Invalid CIDR network value")
+		}
+		ipMask = net.CIDRMask(ones, bits)
+	} else if maskIP := net.ParseIP(mask); maskIP != nil && ipVersion(maskIP) == ipVersion(ip) {
+		if bits == 32 {
+			ipMask = net.IPMask(maskIP.To4())
+		} else {
+			ipMask = net.IPMask(maskIP.To16())
+		}
+		if _, maskBits := ipMask.Size(); maskBits == 0 {
+			return nil, fmt.Errorf("Invalid netmask %s", mask)
+		}
+	} else {
+		return nil, errors.New("Invalid CIDR network value")
+	}
+	if !ip.Equal(ip.Mask(ipMask)) {
+		return nil, errors.New("Invalid CIDR network value")
+	}
+	return &net.IPNet{IP: ip, Mask: ipMask}, nil
+}
+
+// parseRangeHigh parses the right side of an interval, which is either a full IP or a short form
+// replacing the trailing octets (192.168.1.10-20) or groups (FE80::10-20) of the left IP
+func parseRangeHigh(low net.IP, high string) net.IP {
+	if ip := net.ParseIP(high); ip != nil {
+		return ip
+	}
+	if high == "" {
+		return nil
+	}
+	if IsIPv4(low) {
+		fields := strings.Split(high, ".")
+		result := append(net.IP(nil), low.To4()...)
+		if len(fields) >= len(result) {
+			return nil
+		}
+		offset := len(result) - len(fields)
+		for idx, field := range fields {
+			octet, err := strconv.ParseUint(field, 10, 8)
+			if err != nil {
+				return nil
+			}
+			result[offset+idx] = byte(octet)
+		}
+		return result
+	}
+	fields := strings.Split(high, ":")
+	result := append(net.IP(nil), low.To16()...)
+	if len(fields) >= len(result)/2 {
+		return nil
+	}
+	offset := len(result) - 2*len(fields)
+	for idx, field := range fields {
+		group, err := strconv.ParseUint(field, 16, 16)
+		if err != nil {
+			return nil
+		}
+		result[offset+2*idx] = byte(group >> 8)
+		result[offset+2*idx+1] = byte(group)
+	}
+	return result
+}
+
+// parseCIDR avoids the zero address and broadcast address of the network,
+// except for the networks which are too small to have them
+func parseCIDR(ipnet *net.IPNet) *zoneLiteral {
+	ones, bits := ipnet.Mask.Size()
+	local := IPToBigInt(ipnet.IP)
+	offset := new(big.Int).Sub(new(big.Int).Lsh(one, uint(bits-ones)), one)
+	r := &interval{start: local, end: new(big.Int).Add(local, offset)}
+	var excluded []*big.Int
+	if bits-ones > 1 {
+		excluded = []*big.Int{r.start, r.end}
+		r.start = new(big.Int).Add(r.start, one)
+		r.end = new(big.Int).Sub(r.end, one)
+	}
+	return &zoneLiteral{
+		canonical: ipnet.String(),
+		version:   ipVersion(ipnet.IP),
+		intervals: []*interval{r},
+		excluded:  excluded,
+	}
+}
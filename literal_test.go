@@ -0,0 +1,102 @@
+package ipam
+
+import (
+	"testing"
+)
+
+func TestCanonicalLiteral(t *testing.T) {
+	cases := map[string]string{
+		"192.168.0.1":                "192.168.0.1",
+		"FE80:0::12":                 "fe80::12",
+		"192.168.0.0/24":             "192.168.0.0/24",
+		"FE80:0:0::/64":              "fe80::/64",
+		"FE80:0::1-FE80::FF":         "fe80::1-fe80::ff",
+		" 192.168.0.1 - 192.168.0.9": "192.168.0.1-192.168.0.9",
+		"192.168.0.0/255.255.255.0":  "192.168.0.0/24",
+		"192.168.1.10-20":            "192.168.1.10-192.168.1.20",
+		"192.168.1.10-2.20":          "192.168.1.10-192.168.2.20",
+		"FE80::1:10-20":              "fe80::1:10-fe80::1:20",
+		"192.168.3.10-20, 192.168.1.0/255.255.255.0": "192.168.1.0/24,192.168.3.10-192.168.3.20",
+		"10.0.0.6-10.0.0.9,10.0.0.1-10.0.0.5":        "10.0.0.1-10.0.0.9",
+		"10.0.0.1,10.0.0.2-3,10.0.0.5":               "10.0.0.1-10.0.0.3,10.0.0.5",
+		"10.0.0.0/25,10.0.0.128/25":                  "10.0.0.0/25,10.0.0.128/25",
+	}
+	for literal, expected := range cases {
+		canonical, err := CanonicalLiteral(literal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if canonical != expected {
+			t.Fatalf("Canonical literal of %s should be %s, got %s", literal, expected, canonical)
+		}
+	}
+	for _, literal := range []string{"192.168.0.1/24", "192.168.0.9-192.168.0.1", "192.168.0.1-FE80::1", "foo",
+		"192.168.0.0/255.0.255.0", "192.168.1.10-300", "192.168.1.0/24,192.168.1.10-20", "192.168.1.1,FE80::1", "192.168.1.1,"} {
+		if _, err := CanonicalLiteral(literal); err == nil {
+			t.Fatalf("Literal %s should be invalid", literal)
+		}
+	}
+}
+
+func TestEquivalentLiterals(t *testing.T) {
+	ipam := New("test", nil)
+	if err := ipam.AddZone("FE80:0::1-FE80::FF", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.AddZone("fe80::1-fe80::ff", true); err == nil {
+		t.Fatal("Equivalent literal should already exist")
+	}
+	if err := ipam.SetZoneLabel("fe80:0:0::1-fe80::00ff", "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if labels, ok := ipam.ZoneLabels("FE80::1-FE80::FF"); !ok || labels["foo"] != "bar" {
+		t.Fatal("Zone label should be found by an equivalent literal")
+	}
+	if _, err := ipam.DumpZoneAddrs("FE80::0001-FE80::FF", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.RemoveZone("FE80::1-FE80:0::FF"); err != nil {
+		t.Fatal(err)
+	}
+	if len(ipam.Literals()) != 0 {
+		t.Fatal("Zone should be removed by an equivalent literal")
+	}
+}
+
+func TestMultiIntervalZone(t *testing.T) {
+	ipam := New("test", nil)
+	if err := ipam.AddZone("192.168.3.1-2,192.168.1.0/255.255.255.252", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.AddZone("192.168.2.0/24", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.AddZone("192.168.3.2-10", true); err == nil {
+		t.Fatal("Literal should be overlapped")
+	}
+	if idleCount := ipam.IdleCount(); idleCount != "258" {
+		t.Fatalf("Wrong idle count %s", idleCount)
+	}
+	literal := "192.168.1.0/30,192.168.3.1-192.168.3.2"
+	if found := ipam.FindLiteral("192.168.3.2"); found != literal {
+		t.Fatalf("Wrong literal %s", found)
+	}
+	if found := ipam.FindLiteral("192.168.1.3"); found != "" {
+		t.Fatal("Broadcast address should not be contained")
+	}
+	if err := ipam.RemoveZone("192.168.2.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"192.168.1.1", "192.168.1.2", "192.168.3.1", "192.168.3.2"} {
+		ip, err := ipam.AllocAddrNext(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ip.String() != expected {
+			t.Fatalf("Allocated %s, expected %s", ip, expected)
+		}
+	}
+	if _, err := ipam.AllocAddrNext(nil); err == nil {
+		t.Fatal("Zone should be exhausted")
+	}
+}
@@ -0,0 +1,103 @@
+package ipam
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	minMTUIPv4 = 68
+	minMTUIPv6 = 1280
+	maxMTU     = 65535
+	minVlanID  = 1
+	maxVlanID  = 4094
+)
+
+// Allocation is an allocated addr with the network settings of its zone
+type Allocation struct {
+	IP      net.IP
+	Literal string
+	// Nil if the zone has no network settings
+	Network *NetworkConfig
+}
+
+func copyNetworkConfig(config *NetworkConfig) *NetworkConfig {
+	if config == nil {
+		return nil
+	}
+	return &NetworkConfig{
+		Gateway:       config.Gateway,
+		DnsServers:    append([]string(nil), config.DnsServers...),
+		SearchDomains: append([]string(nil), config.SearchDomains...),
+		Mtu:           config.Mtu,
+		VlanId:        config.VlanId,
+	}
+}
+
+func validDomain(domain string) bool {
+	domain = strings.TrimSuffix(domain, ".")
+	if len(domain) <= 0 || len(domain) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) <= 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// normalizeNetworkConfig validates config for zone, and returns a copy with canonical IPs
+func normalizeNetworkConfig(config *NetworkConfig, zone *zone) (*NetworkConfig, error) {
+	result := copyNetworkConfig(config)
+	version := zone.version
+	if result.Gateway != "" {
+		gateway := net.ParseIP(result.Gateway)
+		if gateway == nil {
+			return nil, fmt.Errorf("Invalid gateway %s", result.Gateway)
+		}
+		if ipVersion(gateway) != version {
+			return nil, fmt.Errorf("Gateway %s should be IPv%d", result.Gateway, version)
+		}
+		// zero addresses and broadcast addresses are in the networks of zone but could not be gateways
+		if !zone.Contains(gateway) {
+			return nil, fmt.Errorf("Gateway %s is not in zone %s", result.Gateway, zone.storage.Literal)
+		}
+		result.Gateway = gateway.String()
+	}
+	for idx, server := range result.DnsServers {
+		ip := net.ParseIP(server)
+		if ip == nil {
+			return nil, fmt.Errorf("Invalid DNS server %s", server)
+		}
+		result.DnsServers[idx] = ip.String()
+	}
+	for idx, domain := range result.SearchDomains {
+		if !validDomain(domain) {
+			return nil, fmt.Errorf("Invalid search domain %s", domain)
+		}
+		result.SearchDomains[idx] = strings.ToLower(domain)
+	}
+	if result.Mtu != 0 {
+		minMTU := uint32(minMTUIPv4)
+		if version == 6 {
+			minMTU = minMTUIPv6
+		}
+		if result.Mtu < minMTU || result.Mtu > maxMTU {
+			return nil, fmt.Errorf("MTU %d out of range [%d, %d]", result.Mtu, minMTU, maxMTU)
+		}
+	}
+	// zero VLAN ID is unset
+	if result.VlanId != 0 {
+		if result.VlanId < minVlanID || result.VlanId > maxVlanID {
+			return nil, fmt.Errorf("VLAN ID %d out of range [%d, %d]", result.VlanId, minVlanID, maxVlanID)
+		}
+	}
+	return result, nil
+}
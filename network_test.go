@@ -0,0 +1,80 @@
+package ipam
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestZoneNetworkConfig(t *testing.T) {
+	literal := "192.168.1.0/24"
+	ipam1 := New("test", nil)
+	if err := ipam1.AddZone(literal, true); err != nil {
+		t.Fatal(err)
+	}
+	invalids := []*NetworkConfig{
+		{Gateway: "FE80::1"},
+		{Gateway: "192.168.1"},
+		{Gateway: "192.168.2.1"},
+		{Gateway: "192.168.1.255"},
+		{DnsServers: []string{"8.8.8"}},
+		{SearchDomains: []string{"-foo.example.com"}},
+		{Mtu: 60},
+		{VlanId: 4095},
+	}
+	for _, config := range invalids {
+		if err := ipam1.SetZoneNetworkConfig(literal, config); err == nil {
+			t.Fatalf("Network config %v should be invalid", config)
+		}
+	}
+	config := &NetworkConfig{
+		Gateway:       "192.168.1.254",
+		DnsServers:    []string{"192.168.1.253", "FE80::53"},
+		SearchDomains: []string{"Example.com"},
+		Mtu:           1450,
+		VlanId:        100,
+	}
+	if err := ipam1.SetZoneNetworkConfig(literal, config); err != nil {
+		t.Fatal(err)
+	}
+	allocation, err := ipam1.AllocAddrNextWithConfig(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocation.IP.String() != "192.168.1.1" || allocation.Literal != literal {
+		t.Fatalf("Wrong allocation %s of %s", allocation.IP, allocation.Literal)
+	}
+	if allocation.Network.Gateway != config.Gateway || allocation.Network.DnsServers[1] != "fe80::53" {
+		t.Fatal("Wrong network config of allocation")
+	}
+	if allocation.Network.SearchDomains[0] != "example.com" {
+		t.Fatal("Search domain should be normalized")
+	}
+
+	raw, err := ipam1.Dump(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ipam2 := New("test", nil)
+	if err := ipam2.Load(raw); err != nil {
+		t.Fatal(err)
+	}
+	allocation, err = ipam2.AllocAddrSpecificWithConfig("192.168.1.2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocation.Network.Mtu != config.Mtu || allocation.Network.VlanId != config.VlanId {
+		t.Fatal("Network config should be loaded")
+	}
+	if err := ipam2.SetZoneNetworkConfig(literal, nil); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := ipam2.ZoneNetworkConfig(literal); loaded != nil {
+		t.Fatal("Network config should be cleared")
+	}
+	if err := ipam2.SetZoneNetworkConfig(literal, &NetworkConfig{VlanId: 0}); err != nil {
+		t.Fatal("Zero VLAN ID should be unset")
+	}
+	if err := ipam2.SetZoneNetworkConfig(literal, &NetworkConfig{VlanId: 4095}); err == nil || !strings.Contains(err.Error(), "[1, 4094]") {
+		t.Fatalf("Wrong error of VLAN ID, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package ipam
+
+import (
+	"sort"
+)
+
+// AllocStrategy decides the order of zones used by AllocAddrNext
+type AllocStrategy int
+
+const (
+	// Fill zones in priority order, zones with same priority are ordered by address
+	AllocByPriority AllocStrategy = iota
+	// Spread allocations across zones proportionally to their weights
+	AllocByWeight
+)
+
+// addrLess orders zones by address, IPv4 zones come before IPv6 zones
+func addrLess(a, b *zone) bool {
+	if a.version != b.version {
+		return a.version < b.version
+	}
+	return a.intervals[0].start.Cmp(b.intervals[0].start) < 0
+}
+
+func priorityLess(a, b *zone) bool {
+	if a.storage.Priority != b.storage.Priority {
+		return a.storage.Priority > b.storage.Priority
+	}
+	return addrLess(a, b)
+}
+
+func weightOf(z *zone) uint64 {
+	if z.storage.Weight == 0 {
+		return 1
+	}
+	return uint64(z.storage.Weight)
+}
+
+// sortedZones return zones ordered by address
+func sortedZones(zones map[string]*zone) []*zone {
+	result := make([]*zone, 0, len(zones))
+	for _, z := range zones {
+		result = append(result, z)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return addrLess(result[a], result[b])
+	})
+	return result
+}
+
+// allocOrder return zones in the order to be tried by allocation, zones must be touched before weighting
+func allocOrder(zones map[string]*zone, strategy AllocStrategy) []*zone {
+	result := sortedZones(zones)
+	if strategy != AllocByWeight {
+		sort.SliceStable(result, func(a, b int) bool {
+			return priorityLess(result[a], result[b])
+		})
+		return result
+	}
+	consumed := make(map[*zone]uint64, len(result))
+	for _, z := range result {
+		consumed[z] = uint64(z.UsedCount() + z.ReservedCount())
+	}
+	// the zone with the least consumed/weight comes first
+	sort.SliceStable(result, func(a, b int) bool {
+		za, zb := result[a], result[b]
+		left, right := consumed[za]*weightOf(zb), consumed[zb]*weightOf(za)
+		if left != right {
+			return left < right
+		}
+		return priorityLess(za, zb)
+	})
+	return result
+}
@@ -0,0 +1,148 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// ErrInvalidLabels is wrapped by the errors of labels rejected by a LabelPolicy
+var ErrInvalidLabels = errors.New("Invalid labels")
+
+// LabelTarget is the kind of object labels belong to
+type LabelTarget int
+
+const (
+	TargetIPAM LabelTarget = iota
+	TargetZone
+	TargetAddr
+)
+
+func (t LabelTarget) String() string {
+	switch t {
+	case TargetIPAM:
+		return "IPAM"
+	case TargetZone:
+		return "zone"
+	case TargetAddr:
+		return "addr"
+	}
+	return "unknown"
+}
+
+// LabelPolicy decides which labels are accepted
+type LabelPolicy interface {
+	// Validate is called with the labels of target before and after a change, old is nil for new addrs
+	Validate(target LabelTarget, old, labels LabelMap) error
+}
+
+// LabelSchema is a LabelPolicy built from declarative rules, zero values mean no limit
+type LabelSchema struct {
+	// Pattern all keys should match
+	KeyPattern *regexp.Regexp
+	// Keys every addr should have
+	RequiredKeys []string
+	// Patterns the values of keys should match
+	ValuePatterns map[string]*regexp.Regexp
+	// Allowed values of keys
+	ValueEnums     map[string][]string
+	MaxKeyLength   int
+	MaxValueLength int
+	MaxLabels      int
+	// Keys which could not be changed or removed once set
+	ImmutableKeys []string
+}
+
+func (s *LabelSchema) Validate(target LabelTarget, old, labels LabelMap) error {
+	if s.MaxLabels > 0 && len(labels) > s.MaxLabels {
+		return fmt.Errorf("%w: %s has %d labels, at most %d allowed", ErrInvalidLabels, target, len(labels), s.MaxLabels)
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	// validate in order to get stable errors
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := labels[k]
+		if s.KeyPattern != nil && !s.KeyPattern.MatchString(k) {
+			return fmt.Errorf("%w: key %q does not match %s", ErrInvalidLabels, k, s.KeyPattern)
+		}
+		if s.MaxKeyLength > 0 && len(k) > s.MaxKeyLength {
+			return fmt.Errorf("%w: key %q is longer than %d", ErrInvalidLabels, k, s.MaxKeyLength)
+		}
+		if s.MaxValueLength > 0 && len(v) > s.MaxValueLength {
+			return fmt.Errorf("%w: value of %s is longer than %d", ErrInvalidLabels, k, s.MaxValueLength)
+		}
+		if pattern, ok := s.ValuePatterns[k]; ok && !pattern.MatchString(v) {
+			return fmt.Errorf("%w: value %q of %s does not match %s", ErrInvalidLabels, v, k, pattern)
+		}
+		if enum, ok := s.ValueEnums[k]; ok && !contains(enum, v) {
+			return fmt.Errorf("%w: value %q of %s should be one of %v", ErrInvalidLabels, v, k, enum)
+		}
+	}
+	if target == TargetAddr {
+		for _, k := range s.RequiredKeys {
+			if _, ok := labels[k]; !ok {
+				return fmt.Errorf("%w: %s is required", ErrInvalidLabels, k)
+			}
+		}
+	}
+	for _, k := range s.ImmutableKeys {
+		if v, ok := old[k]; ok {
+			if value, exists := labels[k]; !exists || value != v {
+				return fmt.Errorf("%w: %s is immutable", ErrInvalidLabels, k)
+			}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateLabels validates the labels of target with the policies of IPAM and zone, zone is nil for IPAM labels
+func (i *ipam) validateLabels(z *zone, target LabelTarget, old, labels LabelMap) error {
+	if i.policy != nil {
+		if err := i.policy.Validate(target, old, labels); err != nil {
+			return err
+		}
+	}
+	if z != nil && z.policy != nil {
+		if err := z.policy.Validate(target, old, labels); err != nil {
+			return fmt.Errorf("Zone %s: %w", z.storage.Literal, err)
+		}
+	}
+	return nil
+}
+
+// validateAddrLabels validates the labels an addr of zone would have after fn changes a copy of its labels
+func (i *ipam) validateAddrLabels(z *zone, old *Descriptor, fn func(labels LabelMap)) error {
+	var before LabelMap
+	if old != nil {
+		before = LabelMap(old.Labels)
+	}
+	after := before.Copy()
+	fn(after)
+	return i.validateLabels(z, TargetAddr, before, after)
+}
+
+func (i *ipam) SetLabelPolicy(policy LabelPolicy) {
+	i.policy = policy
+}
+
+func (i *ipam) SetZoneLabelPolicy(literal string, policy LabelPolicy) error {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
+	}
+	zone.policy = policy
+	return nil
+}
@@ -0,0 +1,92 @@
+package ipam
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLabelPolicy(t *testing.T) {
+	schema := &LabelSchema{
+		KeyPattern:     regexp.MustCompile(`^[a-z][a-z0-9-]*$`),
+		RequiredKeys:   []string{"tenant"},
+		ValuePatterns:  map[string]*regexp.Regexp{"tenant": regexp.MustCompile(`^t-[0-9]+$`)},
+		ValueEnums:     map[string][]string{"env": {"prod", "dev"}},
+		MaxValueLength: 16,
+		ImmutableKeys:  []string{"tenant"},
+	}
+	if _, err := NewWithPolicy("test", map[string]string{"Owner": "x"}, schema); !errors.Is(err, ErrInvalidLabels) {
+		t.Fatal("Invalid key should be rejected by NewWithPolicy")
+	}
+	ipam, err := NewWithPolicy("test", map[string]string{"owner": "x"}, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.SetLabel("env", "test"); err == nil || !strings.Contains(err.Error(), "should be one of") {
+		t.Fatalf("Invalid enum value should be rejected, got %v", err)
+	}
+	ipam.AddZone("10.0.0.0/24", true)
+	if err := ipam.SetZoneLabel("10.0.0.0/24", "site", strings.Repeat("x", 17)); err == nil {
+		t.Fatal("Long value should be rejected")
+	}
+
+	if err := ipam.AllocAddrSpecific("10.0.0.1", map[string]string{"app": "web"}); !errors.Is(err, ErrInvalidLabels) {
+		t.Fatalf("Required key should be checked, got %v", err)
+	}
+	if err := ipam.AllocAddrSpecific("10.0.0.1", map[string]string{"tenent": "t-1"}); err == nil {
+		t.Fatal("Missing key should be rejected")
+	}
+	if err := ipam.AllocAddrSpecific("10.0.0.1", map[string]string{"tenant": "foo"}); err == nil {
+		t.Fatal("Invalid value should be rejected")
+	}
+	if err := ipam.AllocAddrSpecific("10.0.0.1", map[string]string{"tenant": "t-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.AllocAddrSpecific("10.0.0.1", map[string]string{"tenant": "t-2"}); err == nil {
+		t.Fatal("Immutable key should not be changed")
+	}
+	if err := ipam.SetAddrLabel("10.0.0.1", "tenant", "t-2"); err == nil {
+		t.Fatal("Immutable key should not be changed")
+	}
+	if err := ipam.RemoveAddrLabel("10.0.0.1", "tenant"); err == nil {
+		t.Fatal("Immutable key should not be removed")
+	}
+	if err := ipam.SetAddrLabel("10.0.0.1", "env", "prod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.ReserveAddr("10.0.0.2", nil); err == nil {
+		t.Fatal("Reservation should be validated")
+	}
+	if labels, _ := ipam.AddrLabels("10.0.0.1"); labels["tenant"] != "t-1" || len(labels) != 2 {
+		t.Fatalf("Rejected changes should not be applied, got %v", labels)
+	}
+	ipam.SetLabel("tenant", "t-1")
+	if _, _, err := ipam.RemoveLabel("tenant"); !errors.Is(err, ErrInvalidLabels) {
+		t.Fatalf("Immutable IPAM label should not be removed, got %v", err)
+	}
+	ipam.SetZoneLabel("10.0.0.0/24", "tenant", "t-1")
+	if _, _, err := ipam.RemoveZoneLabel("10.0.0.0/24", "tenant"); !errors.Is(err, ErrInvalidLabels) {
+		t.Fatalf("Immutable zone label should not be removed, got %v", err)
+	}
+	if labels, _ := ipam.ZoneLabels("10.0.0.0/24"); labels["tenant"] != "t-1" {
+		t.Fatal("Rejected removal should not be applied")
+	}
+
+	ipam.AddZone("10.0.1.0/24", true)
+	ipam.SetZonePriority("10.0.0.0/24", 1, 0)
+	ipam.SetZoneLabelPolicy("10.0.0.0/24", &LabelSchema{ValueEnums: map[string][]string{"env": {"prod"}}})
+	ip, err := ipam.AllocAddrNext(map[string]string{"tenant": "t-1", "env": "dev"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.0.1.1" {
+		t.Fatalf("Zone rejecting labels should be skipped, got %s", ip)
+	}
+	if err := ipam.SetZoneLabelPolicy("10.0.1.0/24", &LabelSchema{MaxLabels: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ipam.AllocAddrNext(map[string]string{"tenant": "t-1", "env": "dev"}); !errors.Is(err, ErrInvalidLabels) {
+		t.Fatalf("Policy error should be returned if no zone accepts labels, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrQuotaExceeded is wrapped by the errors of allocations and reservations exceeding a quota
+var ErrQuotaExceeded = errors.New("Quota exceeded")
+
+func copyQuota(quota *Quota) *Quota {
+	result := *quota
+	result.ZoneLabels = LabelMap(quota.ZoneLabels).Copy()
+	return &result
+}
+
+// quotaLimits return true if zone is limited by quota
+func quotaLimits(quota *Quota, z *zone) bool {
+	if quota.Zone != "" {
+		return quota.Zone == z.storage.Literal
+	}
+	return LabelMap(z.storage.Labels).Includes(quota.ZoneLabels)
+}
+
+func quotaHeldBy(quota *Quota, labels map[string]string) bool {
+	value, ok := labels[quota.Key]
+	return ok && value == quota.Value
+}
+
+// sortedQuotas return quotas ordered by name
+func sortedQuotas(quotas map[string]*Quota) []*Quota {
+	result := make([]*Quota, 0, len(quotas))
+	for _, quota := range quotas {
+		result = append(result, quota)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Name < result[b].Name
+	})
+	return result
+}
+
+func (i *ipam) SetQuota(quota *Quota) error {
+	if quota == nil || quota.Name == "" {
+		return errors.New("Quota name should not be empty")
+	}
+	if quota.Key == "" {
+		return errors.New("Quota key should not be empty")
+	}
+	result := copyQuota(quota)
+	result.Namespace = i.namespace
+	if result.Zone != "" {
+		canonical, err := CanonicalLiteral(result.Zone)
+		if err != nil {
+			return err
+		}
+		result.Zone = canonical
+	}
+	if i.quotas[i.namespace] == nil {
+		i.quotas[i.namespace] = make(map[string]*Quota)
+	}
+	i.quotas[i.namespace][result.Name] = result
+	return nil
+}
+
+func (i *ipam) RemoveQuota(name string) bool {
+	_, ok := i.quotas[i.namespace][name]
+	delete(i.quotas[i.namespace], name)
+	return ok
+}
+
+func (i *ipam) Quotas() []*Quota {
+	result := sortedQuotas(i.quotas[i.namespace])
+	for idx, quota := range result {
+		result[idx] = copyQuota(quota)
+	}
+	return result
+}
+
+func (i *ipam) QuotaUsage(name string) (uint64, uint64, error) {
+	quota, ok := i.quotas[i.namespace][name]
+	if !ok {
+		return 0, 0, fmt.Errorf("Quota %s not exists", name)
+	}
+	used, err := i.quotaUsage(quota)
+	if err != nil {
+		return 0, 0, err
+	}
+	return used, quota.Limit, nil
+}
+
+// quotaUsage counts used and reserved addrs holding the label of quota
+func (i *ipam) quotaUsage(quota *Quota) (uint64, error) {
+	var count uint64
+	for _, zone := range i.zones {
+		if !quotaLimits(quota, zone) {
+			continue
+		}
+		if err := i.touch(zone); err != nil {
+			return 0, err
+		}
+		count += uint64(zone.CountLabel(quota.Key, quota.Value))
+	}
+	return count, nil
+}
+
+// checkQuotas return an error wrapping ErrQuotaExceeded if an addr of zone could not hold labels,
+// old is the descriptor of the addr if it is already used
+func (i *ipam) checkQuotas(zone *zone, old *Descriptor, labels LabelMap) error {
+	for _, quota := range sortedQuotas(i.quotas[i.namespace]) {
+		if !quotaLimits(quota, zone) || !quotaHeldBy(quota, labels) {
+			continue
+		}
+		// the addr is counted already
+		if old != nil && quotaHeldBy(quota, old.Labels) {
+			continue
+		}
+		used, err := i.quotaUsage(quota)
+		if err != nil {
+			return err
+		}
+		if used >= quota.Limit {
+			return fmt.Errorf("%w: %s allows %d addrs with label %s=%s", ErrQuotaExceeded, quota.Name,
+				quota.Limit, quota.Key, quota.Value)
+		}
+	}
+	return nil
+}
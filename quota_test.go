@@ -0,0 +1,79 @@
+package ipam
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuota(t *testing.T) {
+	tenantA := map[string]string{"tenant": "a"}
+	ipam1 := New("test", nil)
+	ipam1.AddZone("192.168.1.0/24", true)
+	ipam1.AddZone("192.168.2.0/24", true)
+	if err := ipam1.SetQuota(&Quota{Name: "a-in-1", Key: "tenant", Value: "a", Zone: "192.168.1.0/255.255.255.0", Limit: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam1.AllocAddrSpecific("192.168.1.1", tenantA); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam1.ReserveAddr("192.168.1.2", tenantA); err != nil {
+		t.Fatal(err)
+	}
+	err := ipam1.AllocAddrSpecific("192.168.1.3", tenantA)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Quota should be exceeded, got %v", err)
+	}
+	// an addr already holding the label is counted
+	if err := ipam1.AllocAddrSpecific("192.168.1.1", tenantA); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam1.AllocAddrSpecific("192.168.1.3", map[string]string{"tenant": "b"}); err != nil {
+		t.Fatal(err)
+	}
+	ip, err := ipam1.AllocAddrNext(tenantA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "192.168.2.1" {
+		t.Fatalf("Zone exceeding quota should be skipped, got %s", ip)
+	}
+	if used, limit, _ := ipam1.QuotaUsage("a-in-1"); used != 2 || limit != 2 {
+		t.Fatalf("Wrong quota usage %d/%d", used, limit)
+	}
+	if err := ipam1.SetAddrLabel("192.168.1.3", "tenant", "a"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Relabeling should be limited by quota, got %v", err)
+	}
+	if _, err := ipam1.UpdateAddrLabels("192.168.1.3", tenantA, nil, 0, UpdateMerge); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Updating labels should be limited by quota, got %v", err)
+	}
+	if err := ipam1.SetAddrLabel("192.168.1.1", "tenant", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	ipam1.SetZoneLabel("192.168.2.0/24", "site", "fra")
+	ipam1.SetQuota(&Quota{Name: "a-in-fra", Key: "tenant", Value: "a", ZoneLabels: map[string]string{"site": "fra"}, Limit: 1})
+	if _, err := ipam1.AllocAddrNext(tenantA); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Quota should be exceeded, got %v", err)
+	}
+	if _, err := ipam1.Namespace("other").AllocAddrNext(tenantA); errors.Is(err, ErrQuotaExceeded) {
+		t.Fatal("Quota should not limit other namespaces")
+	}
+
+	raw, err := ipam1.Dump(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ipam2 := New("test", nil)
+	if err := ipam2.Load(raw); err != nil {
+		t.Fatal(err)
+	}
+	if quotas := ipam2.Quotas(); len(quotas) != 2 || quotas[0].Name != "a-in-1" {
+		t.Fatal("Quotas should be loaded")
+	}
+	if ipam2.RemoveQuota("a-in-fra"); len(ipam2.Quotas()) != 1 {
+		t.Fatal("Quota should be removed")
+	}
+	if _, err := ipam2.AllocAddrNext(tenantA); err != nil {
+		t.Fatal(err)
+	}
+}
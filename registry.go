@@ -0,0 +1,328 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrKeyNotFound is returned by Store when a key not exists
+var ErrKeyNotFound = errors.New("Key not found")
+
+// Store is a key-value storage backend shared by IPAM instances.
+//
+// The thin dump of an instance is saved with its prefix as key, and buckets are saved with their bucket keys.
+type Store interface {
+	// Return ErrKeyNotFound if key not exists
+	Get(key string) ([]byte, error)
+	Put(key string, value []byte) error
+	// Deleting a key not exists is not an error
+	Delete(key string) error
+	// List all keys with prefix
+	Keys(prefix string) ([]string, error)
+}
+
+type memoryStore struct {
+	mutex sync.RWMutex
+	data  map[string][]byte
+}
+
+// NewMemoryStore return a Store keeping everything in memory
+func NewMemoryStore() Store {
+	return &memoryStore{data: make(map[string][]byte)}
+}
+
+func (s *memoryStore) Get(key string) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	value, ok := s.data[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (s *memoryStore) Put(key string, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.data[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *memoryStore) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+func (s *memoryStore) Keys(prefix string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	result := make([]string, 0)
+	for key := range s.data {
+		if strings.HasPrefix(key, prefix) {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+// Owner is the zone which an addr belongs to
+type Owner struct {
+	Prefix    string
+	Namespace string
+	Literal   string
+}
+
+// IPAMRegistry manages named IPAM instances over a shared Store, instances are named by their prefix
+type IPAMRegistry interface {
+	// Create a new instance and save it
+	Create(prefix string, labels LabelMap) (IPAM, error)
+	// Open an instance from store, lazy zones are paged in from store on first access.
+	// Opened instances are cached, so the same IPAM is returned for the same prefix
+	Open(prefix string) (IPAM, error)
+	// Save an opened instance to store, stale buckets are deleted
+	Save(prefix string) error
+	// Delete an instance and all its buckets from store
+	Delete(prefix string) error
+	// List prefixes of all instances
+	List() ([]string, error)
+	// List prefixes of the instances having all labels.
+	// Instances not opened are read from their thin dumps, they are neither paged in nor cached
+	Find(labels LabelMap) ([]string, error)
+	// Find the zones containing an addr across all instances and namespaces, instances are read as Find
+	Owners(specific string) ([]*Owner, error)
+}
+
+type registry struct {
+	store     Store
+	instances map[string]*ipam
+}
+
+// NewRegistry return an IPAMRegistry over store
+func NewRegistry(store Store) IPAMRegistry {
+	return &registry{store: store, instances: make(map[string]*ipam)}
+}
+
+func validPrefix(prefix string) error {
+	if len(prefix) <= 0 || strings.Contains(prefix, "/") {
+		return fmt.Errorf("Invalid prefix %s", prefix)
+	}
+	return nil
+}
+
+func (r *registry) exists(prefix string) (bool, error) {
+	if _, ok := r.instances[prefix]; ok {
+		return true, nil
+	}
+	if _, err := r.store.Get(prefix); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *registry) Create(prefix string, labels LabelMap) (IPAM, error) {
+	if err := validPrefix(prefix); err != nil {
+		return nil, err
+	}
+	exists, err := r.exists(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("IPAM %s already exists", prefix)
+	}
+	i := New(prefix, labels).(*ipam)
+	i.SetBucketLoader(BucketLoaderFunc(r.store.Get))
+	r.instances[prefix] = i
+	if err := r.Save(prefix); err != nil {
+		delete(r.instances, prefix)
+		return nil, err
+	}
+	return i, nil
+}
+
+func (r *registry) Open(prefix string) (IPAM, error) {
+	if err := validPrefix(prefix); err != nil {
+		return nil, err
+	}
+	if i, ok := r.instances[prefix]; ok {
+		return i, nil
+	}
+	raw, err := r.store.Get(prefix)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil, fmt.Errorf("IPAM %s not exists", prefix)
+		}
+		return nil, err
+	}
+	i := New(prefix, nil).(*ipam)
+	i.SetBucketLoader(BucketLoaderFunc(r.store.Get))
+	if err := i.Load(raw); err != nil {
+		return nil, err
+	}
+	// buckets of the zones which are not lazy should be ready after opening
+	for _, zone := range i.allZones() {
+		if zone.lazy {
+			continue
+		}
+		if err := zone.PageIn(i.loader); err != nil {
+			return nil, err
+		}
+	}
+	r.instances[prefix] = i
+	return i, nil
+}
+
+// peek return the opened instance of prefix, or load one from its thin dump without caching.
+// Buckets of the loaded instance are never paged in
+func (r *registry) peek(prefix string) (*ipam, error) {
+	if i, ok := r.instances[prefix]; ok {
+		return i, nil
+	}
+	raw, err := r.store.Get(prefix)
+	if err != nil {
+		return nil, err
+	}
+	i := New(prefix, nil).(*ipam)
+	if err := i.Load(raw); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (r *registry) Save(prefix string) error {
+	i, ok := r.instances[prefix]
+	if !ok {
+		return fmt.Errorf("IPAM %s not opened", prefix)
+	}
+	thin, err := i.Dump(false)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool)
+	for _, zone := range i.allZones() {
+		for key, b := range zone.storage.Buckets {
+			keys[key] = true
+			// buckets not loaded yet are unchanged
+			if b == nil {
+				continue
+			}
+			raw, err := b.Marshal()
+			if err != nil {
+				return fmt.Errorf("Marshal bucket %s failed: %s", key, err)
+			}
+			if err := r.store.Put(key, raw); err != nil {
+				return err
+			}
+		}
+	}
+	if err := r.store.Put(prefix, thin); err != nil {
+		return err
+	}
+	stored, err := r.store.Keys(prefix + "/")
+	if err != nil {
+		return err
+	}
+	for _, key := range stored {
+		if keys[key] {
+			continue
+		}
+		if err := r.store.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *registry) Delete(prefix string) error {
+	if err := validPrefix(prefix); err != nil {
+		return err
+	}
+	exists, err := r.exists(prefix)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("IPAM %s not exists", prefix)
+	}
+	stored, err := r.store.Keys(prefix + "/")
+	if err != nil {
+		return err
+	}
+	for _, key := range stored {
+		if err := r.store.Delete(key); err != nil {
+			return err
+		}
+	}
+	if err := r.store.Delete(prefix); err != nil {
+		return err
+	}
+	delete(r.instances, prefix)
+	return nil
+}
+
+func (r *registry) List() ([]string, error) {
+	keys, err := r.store.Keys("")
+	if err != nil {
+		return nil, err
+	}
+	results := make([]string, 0)
+	for _, key := range keys {
+		// bucket keys always contain a slash
+		if !strings.Contains(key, "/") {
+			results = append(results, key)
+		}
+	}
+	sort.Strings(results)
+	return results, nil
+}
+
+func (r *registry) Find(labels LabelMap) ([]string, error) {
+	prefixes, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]string, 0)
+	for _, prefix := range prefixes {
+		i, err := r.peek(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if i.Labels().Includes(labels) {
+			results = append(results, prefix)
+		}
+	}
+	return results, nil
+}
+
+func (r *registry) Owners(specific string) ([]*Owner, error) {
+	ip := net.ParseIP(specific)
+	if ip == nil {
+		return nil, fmt.Errorf("Invalid IP format %s", specific)
+	}
+	prefixes, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*Owner, 0)
+	for _, prefix := range prefixes {
+		i, err := r.peek(prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range i.sortedNamespaces() {
+			if zone := i.view(name).zoneOf(ip); zone != nil {
+				results = append(results, &Owner{Prefix: prefix, Namespace: name, Literal: zone.storage.Literal})
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,130 @@
+package ipam
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	AddrNumPerBucket = 4
+	defer func() {
+		AddrNumPerBucket = 4096
+	}()
+
+	store := NewMemoryStore()
+	registry1 := NewRegistry(store)
+	site1, err := registry1.Create("site1", map[string]string{"region": "fra"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry1.Create("site1", nil); err == nil {
+		t.Fatal("IPAM site1 should already exist")
+	}
+	if _, err := registry1.Create("site/2", nil); err == nil {
+		t.Fatal("Prefix with slash should be invalid")
+	}
+	site2, err := registry1.Create("site2", map[string]string{"region": "ams"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	site1.AddZone("10.2.0.0/16", true)
+	site1.Namespace("vrf1").AddZone("10.2.3.0/24", false)
+	site2.AddZone("10.3.0.0/16", false)
+	for i := 0; i < 10; i++ {
+		site1.AllocAddrNext(map[string]string{"foo": "bar"})
+		site1.Namespace("vrf1").AllocAddrNext(nil)
+	}
+	for _, prefix := range []string{"site1", "site2"} {
+		if err := registry1.Save(prefix); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	registry2 := NewRegistry(store)
+	if prefixes, _ := registry2.List(); len(prefixes) != 2 || prefixes[0] != "site1" || prefixes[1] != "site2" {
+		t.Fatalf("Wrong instances %v", prefixes)
+	}
+	if prefixes, _ := registry2.Find(map[string]string{"region": "ams"}); len(prefixes) != 1 || prefixes[0] != "site2" {
+		t.Fatalf("Wrong instances %v", prefixes)
+	}
+	owners, err := registry2.Owners("10.2.3.4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owners) != 2 || owners[0].Literal != "10.2.0.0/16" || owners[1].Namespace != "vrf1" {
+		t.Fatalf("Wrong owners of 10.2.3.4")
+	}
+	opened, err := registry2.Open("site1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels, err := opened.AddrLabels("10.2.0.10"); err != nil || labels["foo"] != "bar" {
+		t.Fatal("Addr labels should be loaded from store")
+	}
+	if len(opened.Namespace("vrf1").UsedAddrs()) != 10 {
+		t.Fatal("10 addrs should be loaded in vrf1")
+	}
+
+	for i := 1; i <= 10; i++ {
+		opened.ReleaseAddr("10.2.0." + strconv.Itoa(i))
+	}
+	if err := registry2.Save("site1"); err != nil {
+		t.Fatal(err)
+	}
+	// only the history of released addrs is kept
+	if keys, _ := store.Keys("site1/10.2.0.0/16/"); len(keys) != 1 || keys[0] != "site1/10.2.0.0/16/history" {
+		t.Fatalf("Stale buckets should be deleted, got %v", keys)
+	}
+	if err := registry2.Delete("site1"); err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := store.Keys("site1"); len(keys) != 0 {
+		t.Fatal("All keys of site1 should be deleted")
+	}
+}
+
+type recordStore struct {
+	Store
+	got []string
+}
+
+func (s *recordStore) Get(key string) ([]byte, error) {
+	s.got = append(s.got, key)
+	return s.Store.Get(key)
+}
+
+func TestRegistryFindWithoutOpen(t *testing.T) {
+	store := &recordStore{Store: NewMemoryStore()}
+	registry1 := NewRegistry(store)
+	site1, _ := registry1.Create("site1", map[string]string{"region": "fra"})
+	site1.AddZone("10.2.0.0/16", false)
+	site1.Namespace("vrf1").AddZone("10.2.3.0/24", true)
+	site1.AllocAddrSpecific("10.2.3.4", nil)
+	site1.Namespace("vrf1").AllocAddrSpecific("10.2.3.4", nil)
+	if err := registry1.Save("site1"); err != nil {
+		t.Fatal(err)
+	}
+
+	store.got = nil
+	registry2 := NewRegistry(store)
+	if prefixes, err := registry2.Find(map[string]string{"region": "fra"}); err != nil || len(prefixes) != 1 {
+		t.Fatalf("Wrong instances %v", prefixes)
+	}
+	owners, err := registry2.Owners("10.2.3.4")
+	if err != nil || len(owners) != 2 {
+		t.Fatalf("Wrong owners %v", owners)
+	}
+	for _, key := range store.got {
+		if key != "site1" {
+			t.Fatalf("Only thin dump should be read, got %s", key)
+		}
+	}
+	if len(registry2.(*registry).instances) != 0 {
+		t.Fatal("Instances should not be cached by Find or Owners")
+	}
+	// opened instances are used as they are
+	site1.SetLabel("region", "ams")
+	if prefixes, _ := registry1.Find(map[string]string{"region": "ams"}); len(prefixes) != 1 {
+		t.Fatal("Unsaved labels of opened instance should be found")
+	}
+}
@@ -0,0 +1,300 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Operator is the operator of a selector requirement
+type Operator string
+
+const (
+	OpEquals       Operator = "="
+	OpNotEquals    Operator = "!="
+	OpIn           Operator = "in"
+	OpNotIn        Operator = "notin"
+	OpExists       Operator = "exists"
+	OpDoesNotExist Operator = "!"
+)
+
+// Requirement is a single condition on one label key
+type Requirement struct {
+	Key      string
+	Operator Operator
+	// Sorted, one value for OpEquals and OpNotEquals, none for OpExists and OpDoesNotExist
+	Values []string
+}
+
+func (r *Requirement) Matches(labels LabelMap) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case OpEquals, OpIn:
+		return ok && r.has(value)
+	case OpNotEquals, OpNotIn:
+		return !ok || !r.has(value)
+	case OpExists:
+		return ok
+	case OpDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func (r *Requirement) has(value string) bool {
+	idx := sort.SearchStrings(r.Values, value)
+	return idx < len(r.Values) && r.Values[idx] == value
+}
+
+func (r *Requirement) String() string {
+	switch r.Operator {
+	case OpEquals, OpNotEquals:
+		return r.Key + string(r.Operator) + r.Values[0]
+	case OpIn, OpNotIn:
+		return r.Key + " " + string(r.Operator) + " (" + strings.Join(r.Values, ",") + ")"
+	case OpDoesNotExist:
+		return "!" + r.Key
+	}
+	return r.Key
+}
+
+// Selector is a conjunction of requirements, an empty selector matches everything
+type Selector []*Requirement
+
+func (s Selector) Matches(labels LabelMap) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseSelector parses a comma joined list of requirements in the syntax of kubernetes label selectors:
+// key=value, key==value, key!=value, key in (v1,v2), key notin (v1,v2), key and !key
+func ParseSelector(selector string) (Selector, error) {
+	terms, err := splitSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	result := make(Selector, 0, len(terms))
+	for _, term := range terms {
+		r, err := parseRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// splitSelector splits selector by the commas out of parentheses
+func splitSelector(selector string) ([]string, error) {
+	terms := make([]string, 0)
+	if strings.TrimSpace(selector) == "" {
+		return terms, nil
+	}
+	depth, start := 0, 0
+	for idx, c := range selector {
+		switch c {
+		case '(':
+			if depth++; depth > 1 {
+				return nil, fmt.Errorf("Invalid selector %s: nested parentheses", selector)
+			}
+		case ')':
+			if depth--; depth < 0 {
+				return nil, fmt.Errorf("Invalid selector %s: unbalanced parentheses", selector)
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, strings.TrimSpace(selector[start:idx]))
+				start = idx + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("Invalid selector %s: unbalanced parentheses", selector)
+	}
+	return append(terms, strings.TrimSpace(selector[start:])), nil
+}
+
+func validSelectorToken(token string) bool {
+	return token != "" && !strings.ContainsAny(token, " \t=!(),")
+}
+
+func validSelectorValue(value string) bool {
+	return !strings.ContainsAny(value, " \t=!(),")
+}
+
+func parseRequirement(term string) (*Requirement, error) {
+	if term == "" {
+		return nil, errors.New("Invalid selector: empty requirement")
+	}
+	if strings.HasPrefix(term, "!") && !strings.Contains(term, "=") {
+		key := strings.TrimSpace(term[1:])
+		if !validSelectorToken(key) {
+			return nil, fmt.Errorf("Invalid selector requirement %s", term)
+		}
+		return &Requirement{Key: key, Operator: OpDoesNotExist}, nil
+	}
+	for _, op := range []string{"!=", "==", "="} {
+		if idx := strings.Index(term, op); idx >= 0 {
+			key, value := strings.TrimSpace(term[:idx]), strings.TrimSpace(term[idx+len(op):])
+			if !validSelectorToken(key) || !validSelectorValue(value) {
+				return nil, fmt.Errorf("Invalid selector requirement %s", term)
+			}
+			operator := OpEquals
+			if op == "!=" {
+				operator = OpNotEquals
+			}
+			return &Requirement{Key: key, Operator: operator, Values: []string{value}}, nil
+		}
+	}
+	if idx := strings.Index(term, "("); idx >= 0 {
+		fields := strings.Fields(term[:idx])
+		if len(fields) != 2 || !validSelectorToken(fields[0]) || !strings.HasSuffix(term, ")") {
+			return nil, fmt.Errorf("Invalid selector requirement %s", term)
+		}
+		operator := Operator(fields[1])
+		if operator != OpIn && operator != OpNotIn {
+			return nil, fmt.Errorf("Invalid selector operator %s", fields[1])
+		}
+		values := make([]string, 0)
+		for _, value := range strings.Split(term[idx+1:len(term)-1], ",") {
+			value = strings.TrimSpace(value)
+			if !validSelectorValue(value) {
+				return nil, fmt.Errorf("Invalid selector requirement %s", term)
+			}
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		return &Requirement{Key: fields[0], Operator: operator, Values: values}, nil
+	}
+	if !validSelectorToken(term) {
+		return nil, fmt.Errorf("Invalid selector requirement %s", term)
+	}
+	return &Requirement{Key: term, Operator: OpExists}, nil
+}
+
+// Select calls fn for the used or reserved addrs matching selector in address order, the candidates are
+// taken from the label index unless selector has only negative requirements
+func (z *zone) Select(reserved bool, selector Selector, fn func(entry *AddrEntry) bool) bool {
+	idx, source := z.usedIndex, z.storage.Reserved
+	if reserved {
+		idx = z.reservedIndex
+	}
+	members := idx.lookup(selector)
+	if members == nil {
+		return z.Walk(reserved, nil, func(entry *AddrEntry) bool {
+			if !selector.Matches(entry.Descriptor.Labels) {
+				return true
+			}
+			return fn(entry)
+		})
+	}
+	candidates := make(map[string]*Descriptor, len(members))
+	for addr := range members {
+		var desc *Descriptor
+		if reserved {
+			desc = source[addr]
+		} else {
+			desc, _ = z.GetAddrDesc(net.ParseIP(addr))
+		}
+		if selector.Matches(desc.GetLabels()) {
+			candidates[addr] = desc
+		}
+	}
+	return z.emit(sortedDescs(nil, candidates), fn)
+}
+
+// selectZones return the zones whose labels match zoneSelector ordered by address
+func (i *ipam) selectZones(zoneSelector string) ([]*zone, error) {
+	selector, err := ParseSelector(zoneSelector)
+	if err != nil {
+		return nil, err
+	}
+	zones := i.zones
+	if members := i.zoneIndex(i.namespace).lookup(selector); members != nil {
+		zones = make(map[string]*zone, len(members))
+		for literal := range members {
+			if z, ok := i.zones[literal]; ok {
+				zones[literal] = z
+			}
+		}
+	}
+	result := make([]*zone, 0)
+	for _, z := range sortedZones(zones) {
+		if selector.Matches(z.storage.Labels) {
+			result = append(result, z)
+		}
+	}
+	return result, nil
+}
+
+// ZoneEntry is a zone found by FindZones
+type ZoneEntry struct {
+	Literal   string
+	Namespace string
+	Labels    LabelMap
+	Stats     *ZoneStats
+}
+
+func (i *ipam) FindZones(selector string) ([]*ZoneEntry, error) {
+	zones, err := i.selectZones(selector)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ZoneEntry, 0, len(zones))
+	for _, z := range zones {
+		if err := i.touch(z); err != nil {
+			return nil, err
+		}
+		result = append(result, &ZoneEntry{
+			Literal:   z.storage.Literal,
+			Namespace: z.storage.Namespace,
+			Labels:    LabelMap(z.storage.Labels).Copy(),
+			Stats:     z.Stats(),
+		})
+	}
+	return result, nil
+}
+
+func (i *ipam) find(reserved bool, selector, zoneSelector string) ([]*AddrEntry, error) {
+	addrSelector, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := i.selectZones(zoneSelector)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*AddrEntry, 0)
+	for _, z := range zones {
+		if err := i.touch(z); err != nil {
+			return nil, err
+		}
+		z.Select(reserved, addrSelector, func(entry *AddrEntry) bool {
+			result = append(result, entry)
+			return true
+		})
+	}
+	return result, nil
+}
+
+func (i *ipam) FindAddrs(selector, zoneSelector string) ([]*AddrEntry, error) {
+	return i.find(false, selector, zoneSelector)
+}
+
+func (i *ipam) FindReserved(selector, zoneSelector string) ([]*AddrEntry, error) {
+	return i.find(true, selector, zoneSelector)
+}
@@ -0,0 +1,109 @@
+package ipam
+
+import "testing"
+
+func TestParseSelector(t *testing.T) {
+	labels := LabelMap{"app": "web", "env": "prod"}
+	cases := map[string]bool{
+		"":                              true,
+		"app=web":                       true,
+		"app==web,env=prod":             true,
+		"app!=web":                      false,
+		"app!=db":                       true,
+		"env in (prod, staging)":        true,
+		"env notin (prod,staging)":      false,
+		"app,!tier":                     true,
+		"app=":                          false,
+		"!app":                          false,
+		"tier":                          false,
+		"tier!=x":                       true,
+		"app=web, env in (dev,staging)": false,
+		"app in (web),env notin (dev)":  true,
+	}
+	for s, matched := range cases {
+		selector, err := ParseSelector(s)
+		if err != nil {
+			t.Fatalf("Parse %q failed: %s", s, err)
+		}
+		if selector.Matches(labels) != matched {
+			t.Fatalf("Selector %q should match %v", s, matched)
+		}
+	}
+	for _, s := range []string{"=web", "env in prod", "env in (prod", "env like (a)", "app=web,,env=prod", "!", "a b"} {
+		if _, err := ParseSelector(s); err == nil {
+			t.Fatalf("Selector %q should be invalid", s)
+		}
+	}
+	selector, _ := ParseSelector("env in (staging,prod),!tier")
+	if selector.String() != "env in (prod,staging),!tier" {
+		t.Fatalf("Wrong string of selector %s", selector)
+	}
+}
+
+func TestFindAddrs(t *testing.T) {
+	ipam := New("test", nil)
+	ipam.AddZone("10.0.0.0/24", true)
+	ipam.AddZone("10.0.1.0/24", true)
+	ipam.SetZoneLabel("10.0.1.0/24", "site", "fra")
+	ipam.AllocAddrSpecific("10.0.1.5", map[string]string{"app": "web", "env": "prod"})
+	ipam.AllocAddrSpecific("10.0.0.5", map[string]string{"app": "web", "env": "staging"})
+	ipam.AllocAddrSpecific("10.0.0.6", map[string]string{"app": "web", "env": "dev"})
+	ipam.AllocAddrSpecific("10.0.0.7", map[string]string{"app": "db", "env": "prod"})
+	ipam.ReserveAddr("10.0.0.8", map[string]string{"app": "web"})
+
+	entries, err := ipam.FindAddrs("app=web,env in (prod,staging)", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].IP.String() != "10.0.0.5" || entries[1].IP.String() != "10.0.1.5" {
+		t.Fatalf("Wrong found addrs %v", entries)
+	}
+	if entries[1].Literal != "10.0.1.0/24" || entries[1].Descriptor.Labels["env"] != "prod" {
+		t.Fatalf("Wrong found entry %v", entries[1])
+	}
+	entries, _ = ipam.FindAddrs("app=web", "site=fra")
+	if len(entries) != 1 || entries[0].IP.String() != "10.0.1.5" {
+		t.Fatalf("Wrong found addrs in zones %v", entries)
+	}
+	entries, _ = ipam.FindReserved("app", "!site")
+	if len(entries) != 1 || entries[0].IP.String() != "10.0.0.8" {
+		t.Fatalf("Wrong found reserved addrs %v", entries)
+	}
+	if _, err := ipam.FindAddrs("app in (web", ""); err == nil {
+		t.Fatal("Selector should be invalid")
+	}
+}
+
+func TestFindZones(t *testing.T) {
+	ipam := New("test", nil)
+	for _, literal := range []string{"FE80::/64", "10.0.1.0/24", "10.0.0.0/24", "FE81::/64"} {
+		ipam.AddZone(literal, true)
+	}
+	ipam.SetZoneLabel("FE80::/64", "env", "prod")
+	ipam.SetZoneLabel("FE80::/64", "site", "fra")
+	ipam.SetZoneLabel("10.0.1.0/24", "env", "prod")
+	ipam.SetZoneLabel("10.0.1.0/24", "site", "fra")
+	ipam.SetZoneLabel("10.0.0.0/24", "env", "prod")
+	ipam.SetZoneLabel("FE81::/64", "site", "fra")
+	ipam.AllocAddrSpecific("10.0.1.1", nil)
+
+	zones, err := ipam.FindZones("env=prod,site=fra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zones) != 2 || zones[0].Literal != "10.0.1.0/24" || zones[1].Literal != "fe80::/64" {
+		t.Fatalf("Wrong found zones %v", zones)
+	}
+	if zones[0].Labels["site"] != "fra" || zones[0].Stats.Used.Int64() != 1 || zones[1].Stats.Version != 6 {
+		t.Fatalf("Wrong found zone %v", zones[0])
+	}
+	if zones, _ = ipam.FindZones("!env"); len(zones) != 1 || zones[0].Literal != "fe81::/64" {
+		t.Fatalf("Wrong found zones without env %v", zones)
+	}
+	if zones, _ = ipam.FindZones(""); len(zones) != 4 {
+		t.Fatalf("Empty selector should match all zones, got %v", zones)
+	}
+	if _, err := ipam.FindZones("env in prod"); err == nil {
+		t.Fatal("Selector should be invalid")
+	}
+}
@@ -0,0 +1,112 @@
+package ipam
+
+import (
+	"math/big"
+	"net"
+)
+
+const maxOrderLevel = 32
+
+type orderNode struct {
+	addr *big.Int
+	next []*orderNode
+}
+
+// addrOrder is a skip list of the sorted addrs of a zone, nil means it is built on next walk
+type addrOrder struct {
+	head  *orderNode
+	level int
+	// State of the xorshift generator choosing node levels
+	seed uint64
+}
+
+func newAddrOrder(descs ...map[string]*Descriptor) *addrOrder {
+	o := &addrOrder{head: &orderNode{next: make([]*orderNode, maxOrderLevel)}, level: 1, seed: 1}
+	for _, m := range descs {
+		for addr := range m {
+			o.insert(addr)
+		}
+	}
+	return o
+}
+
+// randomLevel return the level of a new node, a node reaches the next level in a quarter of cases
+func (o *addrOrder) randomLevel() int {
+	level := 1
+	for level < maxOrderLevel {
+		o.seed ^= o.seed << 13
+		o.seed ^= o.seed >> 7
+		o.seed ^= o.seed << 17
+		if o.seed&3 != 0 {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// find fills update with the last nodes before n on every level, and return the first node not less than n
+func (o *addrOrder) find(n *big.Int, update []*orderNode) *orderNode {
+	x := o.head
+	for lv := o.level - 1; lv >= 0; lv-- {
+		for x.next[lv] != nil && x.next[lv].addr.Cmp(n) < 0 {
+			x = x.next[lv]
+		}
+		update[lv] = x
+	}
+	return x.next[0]
+}
+
+func (o *addrOrder) insert(addr string) {
+	if o == nil {
+		return
+	}
+	n := IPToBigInt(net.ParseIP(addr))
+	update := make([]*orderNode, maxOrderLevel)
+	if x := o.find(n, update); x != nil && x.addr.Cmp(n) == 0 {
+		return
+	}
+	level := o.randomLevel()
+	for ; o.level < level; o.level++ {
+		update[o.level] = o.head
+	}
+	node := &orderNode{addr: n, next: make([]*orderNode, level)}
+	for lv := 0; lv < level; lv++ {
+		node.next[lv] = update[lv].next[lv]
+		update[lv].next[lv] = node
+	}
+}
+
+func (o *addrOrder) remove(addr string) {
+	if o == nil {
+		return
+	}
+	n := IPToBigInt(net.ParseIP(addr))
+	update := make([]*orderNode, maxOrderLevel)
+	x := o.find(n, update)
+	if x == nil || x.addr.Cmp(n) != 0 {
+		return
+	}
+	for lv := range x.next {
+		update[lv].next[lv] = x.next[lv]
+	}
+	for o.level > 1 && o.head.next[o.level-1] == nil {
+		o.level--
+	}
+}
+
+// after return the first addr after n, nil n means from the beginning. Return nil if there is none
+func (o *addrOrder) after(n *big.Int) *big.Int {
+	x := o.head
+	if n != nil {
+		for lv := o.level - 1; lv >= 0; lv-- {
+			for x.next[lv] != nil && x.next[lv].addr.Cmp(n) <= 0 {
+				x = x.next[lv]
+			}
+		}
+	}
+	if x.next[0] == nil {
+		return nil
+	}
+	return x.next[0].addr
+}
@@ -0,0 +1,44 @@
+package ipam
+
+import (
+	"math/big"
+	"math/rand"
+	"net"
+	"sort"
+	"testing"
+)
+
+func TestAddrOrder(t *testing.T) {
+	order := newAddrOrder(map[string]*Descriptor{"10.0.0.9": nil, "10.0.0.1": nil})
+	expected := map[int64]bool{9: true, 1: true}
+	base := IPToBigInt(net.ParseIP("10.0.0.0"))
+	rnd := rand.New(rand.NewSource(42))
+	for i := 0; i < 2000; i++ {
+		n := rnd.Int63n(256)
+		addr := BigIntToIP(big.NewInt(0).Add(base, big.NewInt(n)), 4).String()
+		if rnd.Intn(3) == 0 {
+			order.remove(addr)
+			delete(expected, n)
+		} else {
+			order.insert(addr)
+			expected[n] = true
+		}
+	}
+	sorted := make([]int64, 0, len(expected))
+	for n := range expected {
+		sorted = append(sorted, n)
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a] < sorted[b]
+	})
+	idx := 0
+	for n := order.after(nil); n != nil; n = order.after(n) {
+		if idx >= len(sorted) || big.NewInt(0).Add(base, big.NewInt(sorted[idx])).Cmp(n) != 0 {
+			t.Fatalf("Wrong addr %s at %d", n, idx)
+		}
+		idx++
+	}
+	if idx != len(sorted) {
+		t.Fatalf("Walked %d addrs, expected %d", idx, len(sorted))
+	}
+}
@@ -0,0 +1,201 @@
+package ipam
+
+import (
+	"math/big"
+	"time"
+)
+
+// Counts are address counts of zones
+type Counts struct {
+	Capacity *big.Int
+	Used     *big.Int
+	Reserved *big.Int
+	// Capacity - Used - Reserved
+	Idle *big.Int
+	// Used addrs allocated more than one time
+	Shared *big.Int
+}
+
+func newCounts() Counts {
+	return Counts{
+		Capacity: big.NewInt(0),
+		Used:     big.NewInt(0),
+		Reserved: big.NewInt(0),
+		Idle:     big.NewInt(0),
+		Shared:   big.NewInt(0),
+	}
+}
+
+func (c *Counts) add(other *Counts) {
+	c.Capacity.Add(c.Capacity, other.Capacity)
+	c.Used.Add(c.Used, other.Used)
+	c.Reserved.Add(c.Reserved, other.Reserved)
+	c.Idle.Add(c.Idle, other.Idle)
+	c.Shared.Add(c.Shared, other.Shared)
+}
+
+// ZoneStats is the statistics of a zone
+type ZoneStats struct {
+	Literal   string
+	Namespace string
+	Version   uint8
+	Counts
+	// The moment when zone will be exhausted at the recent consuming rate, nil if the zone is not filling up
+	ExhaustionAt *time.Time
+}
+
+// Stats is the statistics of all zones in a namespace
+type Stats struct {
+	// Sum of all zones
+	Counts
+	IPv4 Counts
+	IPv6 Counts
+	// Ordered by address
+	Zones []*ZoneStats
+}
+
+// Stats counts addrs in loaded buckets without listing them
+func (z *zone) Stats() *ZoneStats {
+	stats := &ZoneStats{
+		Literal:   z.storage.Literal,
+		Namespace: z.storage.Namespace,
+		Version:   z.version,
+		Counts:    newCounts(),
+	}
+	var used, shared int64
+	for _, bucket := range z.storage.Buckets {
+		for _, desc := range bucket.GetUsed() {
+			used++
+			if desc.GetRefCount() > 1 {
+				shared++
+			}
+		}
+	}
+	stats.Capacity = z.Size()
+	stats.Used.SetInt64(used)
+	stats.Shared.SetInt64(shared)
+	stats.Reserved.SetInt64(int64(z.ReservedCount()))
+	stats.Idle.Sub(stats.Capacity, stats.Used).Sub(stats.Idle, stats.Reserved)
+	stats.ExhaustionAt = z.forecast(stats.Idle)
+	return stats
+}
+
+func (i *ipam) ZoneStats(literal string) (*ZoneStats, error) {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.touch(zone); err != nil {
+		return nil, err
+	}
+	return zone.Stats(), nil
+}
+
+func (i *ipam) Stats() (*Stats, error) {
+	stats := &Stats{Counts: newCounts(), IPv4: newCounts(), IPv6: newCounts(), Zones: make([]*ZoneStats, 0)}
+	for _, zone := range sortedZones(i.zones) {
+		if err := i.touch(zone); err != nil {
+			return nil, err
+		}
+		zoneStats := zone.Stats()
+		stats.Zones = append(stats.Zones, zoneStats)
+		stats.add(&zoneStats.Counts)
+		if zoneStats.Version == 4 {
+			stats.IPv4.add(&zoneStats.Counts)
+		} else {
+			stats.IPv6.add(&zoneStats.Counts)
+		}
+	}
+	return stats, nil
+}
+
+// UsageCount is the count of used and reserved addrs in a group
+type UsageCount struct {
+	Used     uint64
+	Reserved uint64
+}
+
+// GroupCounts are counts of addrs grouped by the value of a label
+type GroupCounts struct {
+	// Map key is the label value
+	Groups map[string]*UsageCount
+	// Addrs without the label
+	Missing UsageCount
+}
+
+func newGroupCounts() GroupCounts {
+	return GroupCounts{Groups: make(map[string]*UsageCount)}
+}
+
+func (c *GroupCounts) group(value string) *UsageCount {
+	count, ok := c.Groups[value]
+	if !ok {
+		count = &UsageCount{}
+		c.Groups[value] = count
+	}
+	return count
+}
+
+func (c *GroupCounts) add(other *GroupCounts) {
+	for value, count := range other.Groups {
+		sum := c.group(value)
+		sum.Used += count.Used
+		sum.Reserved += count.Reserved
+	}
+	c.Missing.Used += other.Missing.Used
+	c.Missing.Reserved += other.Missing.Reserved
+}
+
+// ZoneGroupCounts are GroupCounts of a zone
+type ZoneGroupCounts struct {
+	Literal   string
+	Namespace string
+	GroupCounts
+}
+
+// GroupReport is the result of CountBy
+type GroupReport struct {
+	Key string
+	// Sum of all zones
+	Total GroupCounts
+	// Ordered by address
+	Zones []*ZoneGroupCounts
+}
+
+// CountBy counts addrs in loaded buckets grouped by the values of label key through the label indexes
+func (z *zone) CountBy(key string) *ZoneGroupCounts {
+	counts := &ZoneGroupCounts{
+		Literal:     z.storage.Literal,
+		Namespace:   z.storage.Namespace,
+		GroupCounts: newGroupCounts(),
+	}
+	var used, reserved uint64
+	for value, members := range z.usedIndex[key] {
+		counts.group(value).Used = uint64(len(members))
+		used += uint64(len(members))
+	}
+	for value, members := range z.reservedIndex[key] {
+		counts.group(value).Reserved = uint64(len(members))
+		reserved += uint64(len(members))
+	}
+	counts.Missing.Used = uint64(z.UsedCount()) - used
+	counts.Missing.Reserved = uint64(z.ReservedCount()) - reserved
+	return counts
+}
+
+func (i *ipam) CountBy(key, zoneSelector string) (*GroupReport, error) {
+	zones, err := i.selectZones(zoneSelector)
+	if err != nil {
+		return nil, err
+	}
+	report := &GroupReport{Key: key, Total: newGroupCounts(), Zones: make([]*ZoneGroupCounts, 0, len(zones))}
+	for _, zone := range zones {
+		if err := i.touch(zone); err != nil {
+			return nil, err
+		}
+		counts := zone.CountBy(key)
+		report.Zones = append(report.Zones, counts)
+		report.Total.add(&counts.GroupCounts)
+	}
+	return report, nil
+}
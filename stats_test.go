@@ -0,0 +1,138 @@
+package ipam
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	ipam := New("test", nil)
+	ipam.AddZone("FE80::/64", true)
+	ipam.AddZone("192.168.1.0/24", true)
+	ipam.AddZone("192.168.2.1-10", true)
+	ipam.AllocAddrSpecific("192.168.1.1", nil)
+	ipam.AllocAddrSpecific("192.168.1.1", nil)
+	ipam.AllocAddrSpecific("192.168.1.2", nil)
+	ipam.ReserveAddr("192.168.1.3", nil)
+	ipam.AllocAddrSpecific("FE80::1", nil)
+
+	zoneStats, err := ipam.ZoneStats("192.168.1.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zoneStats.Capacity.Int64() != 254 || zoneStats.Used.Int64() != 2 || zoneStats.Reserved.Int64() != 1 ||
+		zoneStats.Idle.Int64() != 251 || zoneStats.Shared.Int64() != 1 {
+		t.Fatalf("Wrong zone stats %v", zoneStats.Counts)
+	}
+
+	stats, err := ipam.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Zones) != 3 || stats.Zones[0].Literal != "192.168.1.0/24" || stats.Zones[2].Version != 6 {
+		t.Fatal("Zone stats should be ordered by address")
+	}
+	if stats.IPv4.Capacity.Int64() != 264 || stats.IPv4.Idle.Int64() != 261 {
+		t.Fatalf("Wrong IPv4 stats %v", stats.IPv4)
+	}
+	if stats.IPv6.Capacity.String() != "18446744073709551614" || stats.IPv6.Used.Int64() != 1 {
+		t.Fatalf("Wrong IPv6 stats %v", stats.IPv6)
+	}
+	if stats.Idle.String() != "18446744073709551874" || stats.Idle.String() != ipam.IdleCount() {
+		t.Fatalf("Wrong total idle count %s", stats.Idle)
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	now = func() time.Time {
+		return clock
+	}
+	defer func() {
+		now = time.Now
+	}()
+
+	literal := "192.168.1.1-192.168.1.20"
+	ipam := New("test", nil)
+	ipam.AddZone(literal, true)
+	ipam.AddZone("192.168.2.1-192.168.2.10", true)
+	if err := ipam.SetThresholds(50); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.SetZoneThresholds(literal, 85, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.SetZoneThresholds(literal, 120); err == nil {
+		t.Fatal("Threshold should be out of range")
+	}
+	events := make([]*ThresholdEvent, 0)
+	ipam.OnThreshold(func(event *ThresholdEvent) {
+		events = append(events, event)
+	})
+
+	for i := 1; i <= 17; i++ {
+		clock = clock.Add(time.Minute)
+		ipam.AllocAddrSpecific("192.168.1."+strconv.Itoa(i), nil)
+	}
+	if len(events) != 2 || events[0].Threshold != 10 || events[1].Threshold != 85 || !events[1].Rising {
+		t.Fatalf("Wrong threshold events %v", events)
+	}
+	if events[1].Utilization != 85 || events[1].Literal != literal {
+		t.Fatalf("Wrong threshold event %v", events[1])
+	}
+	stats, _ := ipam.ZoneStats(literal)
+	// 16 addrs in 16 minutes, the remained 3 addrs will be exhausted in 3 minutes
+	if stats.ExhaustionAt == nil || !stats.ExhaustionAt.Equal(clock.Add(3*time.Minute)) {
+		t.Fatalf("Wrong exhaustion forecast %v", stats.ExhaustionAt)
+	}
+	ipam.ReleaseAddr("192.168.1.17")
+	if len(events) != 3 || events[2].Rising || events[2].Threshold != 85 {
+		t.Fatalf("Wrong threshold events %v", events)
+	}
+
+	for i := 1; i <= 5; i++ {
+		ipam.ReserveAddr("192.168.2."+strconv.Itoa(i), nil)
+	}
+	if len(events) != 4 || events[3].Threshold != 50 {
+		t.Fatalf("Default threshold should be used, got %v", events)
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	ipam := New("test", nil)
+	ipam.AddZone("10.0.0.0/24", true)
+	ipam.AddZone("10.0.1.0/24", true)
+	ipam.AddZone("10.0.2.0/24", true)
+	ipam.SetZoneLabel("10.0.0.0/24", "site", "fra")
+	ipam.SetZoneLabel("10.0.1.0/24", "site", "fra")
+	for n := 1; n <= 5; n++ {
+		ipam.AllocAddrSpecific("10.0.0."+strconv.Itoa(n), map[string]string{"team": "a"})
+		ipam.AllocAddrSpecific("10.0.2."+strconv.Itoa(n), map[string]string{"team": "a"})
+	}
+	ipam.AllocAddrSpecific("10.0.1.1", map[string]string{"team": "b"})
+	ipam.AllocAddrSpecific("10.0.1.2", nil)
+	ipam.ReserveAddr("10.0.1.3", map[string]string{"team": "a"})
+	ipam.ReserveAddr("10.0.1.4", nil)
+
+	report, err := ipam.CountBy("team", "site=fra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Zones) != 2 || report.Zones[0].Literal != "10.0.0.0/24" || report.Zones[0].Groups["a"].Used != 5 {
+		t.Fatalf("Wrong zone counts %v", report.Zones)
+	}
+	second := report.Zones[1]
+	if len(second.Groups) != 2 || second.Groups["b"].Used != 1 || second.Groups["a"].Reserved != 1 ||
+		second.Missing.Used != 1 || second.Missing.Reserved != 1 {
+		t.Fatalf("Wrong zone counts %v", second)
+	}
+	total := report.Total
+	if total.Groups["a"].Used != 5 || total.Groups["a"].Reserved != 1 || total.Groups["b"].Used != 1 ||
+		total.Missing.Used != 1 || total.Missing.Reserved != 1 {
+		t.Fatalf("Wrong total counts %v", total)
+	}
+	if report, _ = ipam.CountBy("team", ""); report.Total.Groups["a"].Used != 10 || len(report.Zones) != 3 {
+		t.Fatalf("Wrong total counts of all zones %v", report.Total)
+	}
+}
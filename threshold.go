@@ -0,0 +1,143 @@
+package ipam
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// now is replaced by tests
+var now = time.Now
+
+// SamplesPerZone is the max count of consumption samples kept by a zone for forecasting exhaustion
+var SamplesPerZone = 64
+
+// ThresholdEvent is fired when the utilization of a zone crosses a threshold
+type ThresholdEvent struct {
+	Namespace string
+	Literal   string
+	// The crossed threshold in percentage
+	Threshold float64
+	// Utilization in percentage after the change, which is '(used + reserved) / all'
+	Utilization float64
+	// True if utilization rose to or above threshold, false if it fell below threshold
+	Rising bool
+}
+
+// ThresholdHandler is called synchronously by the call which makes a zone crossing its thresholds
+type ThresholdHandler func(event *ThresholdEvent)
+
+// consumption is a sample of used and reserved addr count at a moment
+type consumption struct {
+	at    time.Time
+	count int64
+}
+
+func validThresholds(thresholds []float64) ([]float64, error) {
+	result := append([]float64(nil), thresholds...)
+	for _, threshold := range result {
+		if threshold <= 0 || threshold > 100 {
+			return nil, fmt.Errorf("Threshold %v out of range (0, 100]", threshold)
+		}
+	}
+	sort.Float64s(result)
+	return result, nil
+}
+
+// Consumed return the count of used and reserved addrs in loaded buckets
+func (z *zone) Consumed() int64 {
+	return int64(z.UsedCount() + z.ReservedCount())
+}
+
+// utilization return the percentage of consumed addrs
+func (z *zone) utilization(consumed int64) float64 {
+	result, _ := new(big.Rat).SetFrac(big.NewInt(consumed*100), z.Size()).Float64()
+	return result
+}
+
+func (z *zone) sample(consumed int64) {
+	z.samples = append(z.samples, consumption{at: now(), count: consumed})
+	if len(z.samples) > SamplesPerZone {
+		z.samples = z.samples[len(z.samples)-SamplesPerZone:]
+	}
+}
+
+// forecast return the moment when zone will be exhausted at the consuming rate of samples,
+// nil if the zone is not filling up
+func (z *zone) forecast(idle *big.Int) *time.Time {
+	if len(z.samples) < 2 {
+		return nil
+	}
+	first, last := z.samples[0], z.samples[len(z.samples)-1]
+	elapsed := last.at.Sub(first.at)
+	if last.count <= first.count || elapsed <= 0 {
+		return nil
+	}
+	// idle * elapsed / (last.count - first.count)
+	remain := new(big.Int).Mul(idle, big.NewInt(int64(elapsed)))
+	remain.Quo(remain, big.NewInt(last.count-first.count))
+	if !remain.IsInt64() {
+		return nil
+	}
+	at := last.at.Add(time.Duration(remain.Int64()))
+	return &at
+}
+
+func (i *ipam) SetThresholds(thresholds ...float64) error {
+	result, err := validThresholds(thresholds)
+	if err != nil {
+		return err
+	}
+	i.thresholds = result
+	return nil
+}
+
+func (i *ipam) SetZoneThresholds(literal string, thresholds ...float64) error {
+	zone, err := i.getZone(literal)
+	if err != nil {
+		return err
+	}
+	result, err := validThresholds(thresholds)
+	if err != nil {
+		return err
+	}
+	zone.storage.Thresholds = result
+	return nil
+}
+
+func (i *ipam) OnThreshold(handler ThresholdHandler) {
+	i.thresholdHandler = handler
+}
+
+// track samples the consumption of zone after a change and fires threshold events,
+// before is the consumed count of zone before the change
+func (i *ipam) track(zone *zone, before int64) {
+	after := zone.Consumed()
+	if after == before {
+		return
+	}
+	zone.sample(after)
+	if i.thresholdHandler == nil {
+		return
+	}
+	thresholds := zone.storage.Thresholds
+	if len(thresholds) <= 0 {
+		thresholds = i.thresholds
+	}
+	low, high := zone.utilization(before), zone.utilization(after)
+	for _, threshold := range thresholds {
+		rising := low < threshold && high >= threshold
+		falling := low >= threshold && high < threshold
+		if !rising && !falling {
+			continue
+		}
+		i.thresholdHandler(&ThresholdEvent{
+			Namespace:   zone.storage.Namespace,
+			Literal:     zone.storage.Literal,
+			Threshold:   threshold,
+			Utilization: high,
+			Rising:      rising,
+		})
+	}
+}
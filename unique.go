@@ -0,0 +1,278 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+)
+
+// ErrLabelConflict is wrapped by the errors of labels violating a unique constraint
+var ErrLabelConflict = errors.New("Label conflict")
+
+// Scopes of unique labels
+const (
+	UniqueInZone int32 = iota
+	UniqueInNamespace
+	UniqueInIPAM
+)
+
+// copyUniqueLabel return a copy of unique without the holders
+func copyUniqueLabel(unique *UniqueLabel) *UniqueLabel {
+	return &UniqueLabel{Key: unique.Key, Namespace: unique.Namespace, Scope: unique.Scope}
+}
+
+// uniqueLabels return the unique constraints applied to the zones of namespace
+func (ins *instance) uniqueLabels(namespace string) []*UniqueLabel {
+	result := make([]*UniqueLabel, 0)
+	for ns, uniques := range ins.uniques {
+		for _, unique := range uniques {
+			if ns == namespace || unique.Scope == UniqueInIPAM {
+				result = append(result, unique)
+			}
+		}
+	}
+	// check in order to get stable errors
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Key != result[b].Key {
+			return result[a].Key < result[b].Key
+		}
+		return result[a].Namespace < result[b].Namespace
+	})
+	return result
+}
+
+// inScope return true if holder is in the scope of unique for an addr of zone z
+func inScope(unique *UniqueLabel, holder *UniqueHolder, z *zone) bool {
+	switch unique.Scope {
+	case UniqueInZone:
+		return holder.Namespace == z.storage.Namespace && holder.Literal == z.storage.Literal
+	case UniqueInNamespace:
+		return holder.Namespace == z.storage.Namespace
+	}
+	return true
+}
+
+func lessHolder(a, b *UniqueHolder) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return IPToBigInt(net.ParseIP(a.Addr)).Cmp(IPToBigInt(net.ParseIP(b.Addr))) < 0
+}
+
+// addHolder adds holder to the holders of label value of unique
+func addHolder(unique *UniqueLabel, value string, holder *UniqueHolder) {
+	if unique.Holders == nil {
+		unique.Holders = make(map[string]*UniqueHolders)
+	}
+	holders, ok := unique.Holders[value]
+	if !ok {
+		holders = &UniqueHolders{}
+		unique.Holders[value] = holders
+	}
+	idx := sort.Search(len(holders.Holders), func(idx int) bool {
+		return !lessHolder(holders.Holders[idx], holder)
+	})
+	if idx < len(holders.Holders) && !lessHolder(holder, holders.Holders[idx]) {
+		return
+	}
+	holders.Holders = append(holders.Holders, nil)
+	copy(holders.Holders[idx+1:], holders.Holders[idx:])
+	holders.Holders[idx] = holder
+}
+
+// removeHolders removes the holders of label value of unique for which fn returns true
+func removeHolders(unique *UniqueLabel, value string, fn func(holder *UniqueHolder) bool) {
+	holders, ok := unique.Holders[value]
+	if !ok {
+		return
+	}
+	kept := holders.Holders[:0]
+	for _, holder := range holders.Holders {
+		if !fn(holder) {
+			kept = append(kept, holder)
+		}
+	}
+	if holders.Holders = kept; len(kept) <= 0 {
+		delete(unique.Holders, value)
+	}
+}
+
+// updateHolders keeps the holders of unique labels in sync after the labels of ip in zone z changed from old to labels
+func (i *ipam) updateHolders(z *zone, ip net.IP, old, labels LabelMap) {
+	addr := ip.String()
+	for _, unique := range i.uniqueLabels(z.storage.Namespace) {
+		if value, ok := old[unique.Key]; ok {
+			removeHolders(unique, value, func(holder *UniqueHolder) bool {
+				return holder.Namespace == z.storage.Namespace && holder.Addr == addr
+			})
+		}
+		if value, ok := labels[unique.Key]; ok {
+			addHolder(unique, value, &UniqueHolder{Namespace: z.storage.Namespace, Literal: z.storage.Literal, Addr: addr})
+		}
+	}
+}
+
+// dropHolders removes the holders in zone z from the unique labels
+func (i *ipam) dropHolders(z *zone) {
+	for _, unique := range i.uniqueLabels(z.storage.Namespace) {
+		for value := range unique.Holders {
+			removeHolders(unique, value, func(holder *UniqueHolder) bool {
+				return holder.Namespace == z.storage.Namespace && holder.Literal == z.storage.Literal
+			})
+		}
+	}
+}
+
+// holdIndexed adds the used and reserved addrs of zone z having the label of unique to its holders
+func holdIndexed(unique *UniqueLabel, z *zone) {
+	for _, idx := range []labelIndex{z.usedIndex, z.reservedIndex} {
+		for value, members := range idx[unique.Key] {
+			for addr := range members {
+				addHolder(unique, value, &UniqueHolder{Namespace: z.storage.Namespace, Literal: z.storage.Literal, Addr: addr})
+			}
+		}
+	}
+}
+
+// checkUnique return an error wrapping ErrLabelConflict if an addr other than ip holds one of the unique labels,
+// ip is nil for a new addr. Only the holders of unique labels are checked, so no bucket is paged in
+func (i *ipam) checkUnique(z *zone, ip net.IP, labels LabelMap) error {
+	for _, unique := range i.uniqueLabels(z.storage.Namespace) {
+		value, ok := labels[unique.Key]
+		if !ok {
+			continue
+		}
+		for _, holder := range unique.Holders[value].GetHolders() {
+			if !inScope(unique, holder, z) {
+				continue
+			}
+			if ip != nil && holder.Namespace == z.storage.Namespace && holder.Addr == ip.String() {
+				continue
+			}
+			return fmt.Errorf("%w: %s=%s is held by IP %s in zone %s", ErrLabelConflict, unique.Key, value,
+				holder.Addr, holder.Literal)
+		}
+	}
+	return nil
+}
+
+func (i *ipam) SetUniqueLabel(key string, scope int32) error {
+	if key == "" {
+		return errors.New("Unique label key should not be empty")
+	}
+	if scope < UniqueInZone || scope > UniqueInIPAM {
+		return fmt.Errorf("Invalid unique label scope %d", scope)
+	}
+	// existing labels should satisfy the constraint in every scope
+	var scopes [][]*zone
+	switch scope {
+	case UniqueInZone:
+		for _, z := range sortedZones(i.zones) {
+			scopes = append(scopes, []*zone{z})
+		}
+	case UniqueInNamespace:
+		scopes = [][]*zone{sortedZones(i.zones)}
+	case UniqueInIPAM:
+		scopes = [][]*zone{i.allZones()}
+	}
+	for _, zones := range scopes {
+		seen := make(map[string]*AddrEntry)
+		for _, other := range zones {
+			if err := i.touch(other); err != nil {
+				return err
+			}
+			for _, idx := range []labelIndex{other.usedIndex, other.reservedIndex} {
+				for value, members := range idx[key] {
+					for addr := range members {
+						if held, ok := seen[value]; ok {
+							return fmt.Errorf("%w: %s=%s is held by IP %s in zone %s and IP %s in zone %s",
+								ErrLabelConflict, key, value, held.IP, held.Literal, addr, other.storage.Literal)
+						}
+						seen[value] = &AddrEntry{IP: net.ParseIP(addr), Literal: other.storage.Literal}
+					}
+				}
+			}
+		}
+	}
+	unique := &UniqueLabel{Key: key, Namespace: i.namespace, Scope: scope}
+	for _, zones := range scopes {
+		for _, z := range zones {
+			holdIndexed(unique, z)
+		}
+	}
+	if i.uniques[i.namespace] == nil {
+		i.uniques[i.namespace] = make(map[string]*UniqueLabel)
+	}
+	i.uniques[i.namespace][key] = unique
+	return nil
+}
+
+// uniqueLabel return the unique constraint of key in namespace, or the one of UniqueInIPAM scope defined in
+// any namespace
+func (ins *instance) uniqueLabel(namespace, key string) (*UniqueLabel, bool) {
+	if unique, ok := ins.uniques[namespace][key]; ok {
+		return unique, true
+	}
+	var result *UniqueLabel
+	for _, uniques := range ins.uniques {
+		unique, ok := uniques[key]
+		// the one of the least namespace is chosen to keep the result deterministic
+		if ok && unique.Scope == UniqueInIPAM && (result == nil || unique.Namespace < result.Namespace) {
+			result = unique
+		}
+	}
+	return result, result != nil
+}
+
+func (i *ipam) RemoveUniqueLabel(key string) bool {
+	unique, ok := i.uniqueLabel(i.namespace, key)
+	if !ok {
+		return false
+	}
+	delete(i.uniques[unique.Namespace], key)
+	return true
+}
+
+// sortedUniqueLabels return the unique labels ordered by key
+func sortedUniqueLabels(uniques map[string]*UniqueLabel) []*UniqueLabel {
+	result := make([]*UniqueLabel, 0, len(uniques))
+	for _, unique := range uniques {
+		result = append(result, unique)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Key < result[b].Key
+	})
+	return result
+}
+
+func (i *ipam) UniqueLabels() []*UniqueLabel {
+	result := sortedUniqueLabels(i.uniques[i.namespace])
+	for idx, unique := range result {
+		result[idx] = copyUniqueLabel(unique)
+	}
+	return result
+}
+
+func (i *ipam) LookupByUniqueLabel(key, value string) ([]*AddrEntry, error) {
+	unique, ok := i.uniqueLabel(i.namespace, key)
+	if !ok {
+		return nil, fmt.Errorf("Label %s is not unique", key)
+	}
+	result := make([]*AddrEntry, 0)
+	for _, holder := range unique.Holders[value].GetHolders() {
+		z, ok := i.namespaces[holder.Namespace][holder.Literal]
+		if !ok {
+			continue
+		}
+		// only the zones of holders are paged in
+		if err := i.touch(z); err != nil {
+			return nil, err
+		}
+		ip := net.ParseIP(holder.Addr)
+		if desc, ok := z.Describe(ip); ok {
+			result = append(result, z.entry(IPToBigInt(ip), desc))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package ipam
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUniqueLabels(t *testing.T) {
+	ipam1 := New("test", nil)
+	ipam1.AddZone("10.0.0.0/24", true)
+	ipam1.AddZone("10.0.1.0/24", true)
+	ipam1.Namespace("vrf").AddZone("10.0.0.0/24", true)
+	ipam1.AllocAddrSpecific("10.0.0.1", map[string]string{"host": "a"})
+	ipam1.AllocAddrSpecific("10.0.1.1", map[string]string{"host": "a"})
+
+	if err := ipam1.SetUniqueLabel("host", UniqueInNamespace); !errors.Is(err, ErrLabelConflict) {
+		t.Fatalf("Existing duplicates should be rejected, got %v", err)
+	}
+	if err := ipam1.SetUniqueLabel("host", UniqueInZone); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam1.SetUniqueLabel("mac", UniqueInIPAM); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam1.SetUniqueLabel("mac", 3); err == nil {
+		t.Fatal("Invalid scope should be rejected")
+	}
+
+	err := ipam1.AllocAddrSpecific("10.0.0.2", map[string]string{"host": "a"})
+	if !errors.Is(err, ErrLabelConflict) || !strings.Contains(err.Error(), "10.0.0.1") {
+		t.Fatalf("Conflict should name the holding addr, got %v", err)
+	}
+	if err := ipam1.AllocAddrSpecific("10.0.0.1", map[string]string{"host": "a"}); err != nil {
+		t.Fatal("Holding addr could be allocated again")
+	}
+	ipam1.AllocAddrSpecific("10.0.0.2", map[string]string{"host": "b", "mac": "aa:bb"})
+	if err := ipam1.ReserveAddr("10.0.0.3", map[string]string{"host": "b"}); !errors.Is(err, ErrLabelConflict) {
+		t.Fatalf("Reservation should be checked, got %v", err)
+	}
+	if err := ipam1.SetAddrLabel("10.0.0.1", "host", "b"); !errors.Is(err, ErrLabelConflict) {
+		t.Fatalf("Label update should be checked, got %v", err)
+	}
+	if _, err := ipam1.UpdateAddrLabels("10.0.1.1", map[string]string{"mac": "aa:bb"}, nil, 0, UpdateMerge); !errors.Is(err, ErrLabelConflict) {
+		t.Fatalf("Label update should be checked, got %v", err)
+	}
+	if err := ipam1.Namespace("vrf").AllocAddrSpecific("10.0.0.2", map[string]string{"mac": "aa:bb"}); !errors.Is(err, ErrLabelConflict) {
+		t.Fatalf("Unique label in IPAM should be checked in other namespaces, got %v", err)
+	}
+	if err := ipam1.Namespace("vrf").AllocAddrSpecific("10.0.0.2", map[string]string{"host": "b"}); err != nil {
+		t.Fatal(err)
+	}
+	ipam1.SetZonePriority("10.0.1.0/24", 1, 0)
+	ip, err := ipam1.AllocAddrNext(map[string]string{"host": "a"})
+	if err == nil {
+		t.Fatalf("Addr %s should not be allocated", ip)
+	}
+	ipam1.ReleaseAddr("10.0.1.1")
+	if ip, err = ipam1.AllocAddrNext(map[string]string{"host": "a"}); err != nil || ip.String() != "10.0.1.1" {
+		t.Fatalf("Zone without the holder should be chosen, got %s %v", ip, err)
+	}
+
+	entries, err := ipam1.LookupByUniqueLabel("host", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].IP.String() != "10.0.0.1" || entries[1].IP.String() != "10.0.1.1" {
+		t.Fatalf("Wrong holders %v", entries)
+	}
+	if _, err := ipam1.LookupByUniqueLabel("app", "a"); err == nil {
+		t.Fatal("Label should not be unique")
+	}
+
+	raw, _ := ipam1.Dump(true)
+	ipam2 := New("test", nil)
+	ipam2.Load(raw)
+	if uniques := ipam2.UniqueLabels(); len(uniques) != 2 || uniques[0].Key != "host" || uniques[1].Scope != UniqueInIPAM {
+		t.Fatalf("Unique labels should be loaded, got %v", uniques)
+	}
+	if entries, _ := ipam2.LookupByUniqueLabel("mac", "aa:bb"); len(entries) != 1 || entries[0].IP.String() != "10.0.0.2" {
+		t.Fatalf("Wrong holders %v", entries)
+	}
+	vrf := ipam2.Namespace("vrf")
+	if entries, _ := vrf.LookupByUniqueLabel("mac", "aa:bb"); len(entries) != 1 || entries[0].Namespace != DefaultNamespace {
+		t.Fatalf("IPAM scope label should be looked up from other namespaces, got %v", entries)
+	}
+	if _, err := vrf.LookupByUniqueLabel("host", "a"); err == nil {
+		t.Fatal("Namespace scope label should not be looked up from other namespaces")
+	}
+	if !vrf.RemoveUniqueLabel("mac") || vrf.AllocAddrSpecific("10.0.0.2", map[string]string{"mac": "aa:bb"}) != nil {
+		t.Fatal("Removed unique label should not be checked")
+	}
+}
+
+func TestUniqueLabelsOfLazyZones(t *testing.T) {
+	ipam1 := New("test", nil)
+	ipam1.AddZone("10.0.0.0/24", true)
+	ipam1.Namespace("vrf").AddZone("10.0.1.0/24", true)
+	ipam1.SetUniqueLabel("mac", UniqueInIPAM)
+	ipam1.AllocAddrSpecific("10.0.0.1", map[string]string{"mac": "aa:bb"})
+	ipam1.Namespace("vrf").AllocAddrSpecific("10.0.1.1", map[string]string{"mac": "cc:dd"})
+	thin, _ := ipam1.Dump(false)
+	stored := make(map[string][]byte)
+	for _, ipm := range []IPAM{ipam1, ipam1.Namespace("vrf")} {
+		for _, literal := range ipm.Literals() {
+			addrs, _ := ipm.DumpZoneAddrs(literal, false)
+			for key, raw := range addrs {
+				stored[key] = raw
+			}
+		}
+	}
+
+	fetched := make(map[string]bool)
+	ipam2 := New("test", nil)
+	ipam2.SetBucketLoader(BucketLoaderFunc(func(key string) ([]byte, error) {
+		fetched[key] = true
+		return stored[key], nil
+	}))
+	if err := ipam2.Load(thin); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam2.AllocAddrSpecific("10.0.0.2", map[string]string{"mac": "cc:dd"}); !errors.Is(err, ErrLabelConflict) ||
+		!strings.Contains(err.Error(), "10.0.1.1") {
+		t.Fatalf("Holders should be loaded from thin dump, got %v", err)
+	}
+	for key := range fetched {
+		if strings.Contains(key, "/vrf/") {
+			t.Fatalf("Bucket %s of the holding zone should not be fetched for checking", key)
+		}
+	}
+	ipam2.ReleaseAddr("10.0.0.1")
+	if err := ipam2.Namespace("vrf").AllocAddrSpecific("10.0.1.2", map[string]string{"mac": "aa:bb"}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ipam2.LookupByUniqueLabel("mac", "aa:bb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].IP.String() != "10.0.1.2" || entries[0].Namespace != "vrf" {
+		t.Fatalf("Wrong holders %v", entries)
+	}
+	ipam2.Namespace("vrf").RemoveZone("10.0.1.0/24")
+	if entries, _ := ipam2.LookupByUniqueLabel("mac", "cc:dd"); len(entries) != 0 {
+		t.Fatal("Holders of removed zone should be dropped")
+	}
+	if err := ipam2.AllocAddrSpecific("10.0.0.3", map[string]string{"mac": "cc:dd"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,7 @@
 package ipam
 
 import (
+	"fmt"
 	"math/big"
 	"net"
 	"strconv"
@@ -11,27 +12,137 @@ const BucketSize = (512 + 1024) * 1024
 var AddrNumPerBucket = 4096
 
 type zone struct {
-	start   *big.Int
-	end     *big.Int
-	version uint8
-	lazy    bool
-	storage *Zone
+	// Sorted and disjoint
+	intervals []*interval
+	// Addrs in the networks of zone but not available, such as broadcast addresses
+	excluded []*big.Int
+	version  uint8
+	lazy     bool
+	storage  *Zone
+	// Recent consumptions for forecasting exhaustion
+	samples []consumption
+	// Label indexes of used addrs in loaded buckets and reserved addrs
+	usedIndex     labelIndex
+	reservedIndex labelIndex
+	// Sorted used addrs in loaded buckets and reserved addrs for walking
+	usedOrder     *addrOrder
+	reservedOrder *addrOrder
+	// Policy validating labels of zone and its addrs, it is not persisted
+	policy LabelPolicy
+}
+
+func newZone(lit *zoneLiteral, storage *Zone, lazy bool) *zone {
+	z := &zone{
+		intervals: lit.intervals,
+		excluded:  lit.excluded,
+		version:   lit.version,
+		lazy:      lazy,
+		storage:   storage,
+	}
+	z.reindex()
+	return z
 }
 
 func (z *zone) Contains(ip net.IP) bool {
+	if ipVersion(ip) != z.version {
+		return false
+	}
+	ipBigInt := IPToBigInt(ip)
+	for _, r := range z.intervals {
+		if r.Contains(ipBigInt) {
+			return true
+		}
+	}
+	return false
+}
+
+// Excludes return true if ip is in the networks of zone but not available
+func (z *zone) Excludes(ip net.IP) bool {
+	if ipVersion(ip) != z.version {
+		return false
+	}
 	ipBigInt := IPToBigInt(ip)
-	return z.start.Cmp(ipBigInt) <= 0 && z.end.Cmp(ipBigInt) >= 0
+	for _, excluded := range z.excluded {
+		if excluded.Cmp(ipBigInt) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (z *zone) Overlapped(other *zone) bool {
+	if z.version != other.version {
+		return false
+	}
+	for _, r := range z.intervals {
+		for _, o := range other.intervals {
+			if r.Overlapped(o) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Size return the address count of zone
+func (z *zone) Size() *big.Int {
+	size := big.NewInt(0)
+	for _, r := range z.intervals {
+		size.Add(size, r.Size())
+	}
+	return size
+}
+
+// PageIn fetches the buckets which are not loaded yet through loader
+func (z *zone) PageIn(loader BucketLoader) error {
+	for key, b := range z.storage.Buckets {
+		if b != nil {
+			continue
+		}
+		raw, err := loader.LoadBucket(key)
+		if err != nil {
+			return fmt.Errorf("Load bucket %s failed: %s", key, err)
+		}
+		bucket := &Bucket{}
+		if err := bucket.Unmarshal(raw); err != nil {
+			return fmt.Errorf("Unmarshal bucket %s failed: %s", key, err)
+		}
+		if bucket.Used == nil {
+			bucket.Used = make(map[string]*Descriptor)
+		}
+		z.storage.Buckets[key] = bucket
+		z.indexBucket(bucket)
+	}
+	return nil
 }
 
 func (z *zone) IPUsed(ip net.IP) bool {
 	for _, bucket := range z.storage.Buckets {
-		if _, ok := bucket.Used[ip.String()]; ok {
+		if _, ok := bucket.GetUsed()[ip.String()]; ok {
 			return ok
 		}
 	}
 	return false
 }
 
+// UsedCount return the count of used addrs in loaded buckets
+func (z *zone) UsedCount() int {
+	count := 0
+	for _, bucket := range z.storage.Buckets {
+		count += len(bucket.GetUsed())
+	}
+	return count
+}
+
+func (z *zone) ReservedCount() int {
+	return len(z.storage.Reserved)
+}
+
+// CountLabel return the count of used and reserved addrs having label key=value
+func (z *zone) CountLabel(key, value string) int {
+	return z.usedIndex.count(key, value) + z.reservedIndex.count(key, value)
+}
+
 func (z *zone) IPReserved(ip net.IP) bool {
 	if z.storage.Reserved == nil {
 		return false
@@ -40,98 +151,174 @@ func (z *zone) IPReserved(ip net.IP) bool {
 	return ok
 }
 
+// Locate return the descriptor of an used or reserved ip, and the key of bucket holding it if used
+func (z *zone) Locate(ip net.IP) (*Descriptor, string, bool) {
+	if desc, ok := z.storage.Reserved[ip.String()]; ok {
+		return desc, "", true
+	}
+	for key, bucket := range z.storage.Buckets {
+		if desc, ok := bucket.GetUsed()[ip.String()]; ok {
+			return desc, key, true
+		}
+	}
+	return nil, "", false
+}
+
 func (z *zone) GetAddrDesc(ip net.IP) (*Descriptor, bool) {
 	for _, bucket := range z.storage.Buckets {
-		if desc, ok := bucket.Used[ip.String()]; ok {
+		if desc, ok := bucket.GetUsed()[ip.String()]; ok {
 			return desc, ok
 		}
 	}
 	return nil, false
 }
 
-func (z *zone) SetAddrLabel(ip net.IP, key, value string) bool {
+// Describe return the descriptor of an used or reserved ip, a nil descriptor is replaced by an empty one
+func (z *zone) Describe(ip net.IP) (*Descriptor, bool) {
+	addr := ip.String()
+	if desc, ok := z.storage.Reserved[addr]; ok {
+		if desc == nil {
+			desc = &Descriptor{}
+			z.storage.Reserved[addr] = desc
+		}
+		return desc, true
+	}
 	for _, bucket := range z.storage.Buckets {
-		if desc, ok := bucket.Used[ip.String()]; ok {
+		if desc, ok := bucket.GetUsed()[addr]; ok {
 			if desc == nil {
-				bucket.Used[ip.String()] = &Descriptor{Labels: map[string]string{key: value}}
-			} else if desc.Labels == nil {
-				desc.Labels = map[string]string{key: value}
-			} else {
-				desc.Labels[key] = value
+				desc = &Descriptor{}
+				bucket.Used[addr] = desc
 			}
-			return true
+			return desc, true
 		}
 	}
-	return false
+	return nil, false
 }
 
-func (z *zone) RemoveAddrLabel(ip net.IP, key string) bool {
-	for _, bucket := range z.storage.Buckets {
-		if desc, ok := bucket.Used[ip.String()]; ok {
-			delete(desc.Labels, key)
-			return true
-		}
+// Update calls fn with the descriptor of an used or reserved ip, increases its revision and keeps the indexes in sync
+func (z *zone) Update(ip net.IP, fn func(desc *Descriptor)) bool {
+	desc, ok := z.Describe(ip)
+	if !ok {
+		return false
 	}
-	return false
+	idx := z.usedIndex
+	if z.IPReserved(ip) {
+		idx = z.reservedIndex
+	}
+	idx.remove(ip.String(), desc.Labels)
+	fn(desc)
+	desc.Revision++
+	idx.add(ip.String(), desc.Labels)
+	return true
+}
+
+func (z *zone) SetAddrLabel(ip net.IP, key, value string) bool {
+	return z.Update(ip, func(desc *Descriptor) {
+		if desc.Labels == nil {
+			desc.Labels = make(map[string]string)
+		}
+		desc.Labels[key] = value
+	})
+}
+
+func (z *zone) RemoveAddrLabel(ip net.IP, key string) bool {
+	return z.Update(ip, func(desc *Descriptor) {
+		delete(desc.Labels, key)
+	})
 }
 
-func (z *zone) ReserveAddr(_ net.IP, _ *Descriptor) {
+func (z *zone) ReserveAddr(ip net.IP, desc *Descriptor) {
 	if z.storage.Reserved == nil {
 		z.storage.Reserved = make(map[string]*Descriptor)
 	}
-	// TODO
+	z.storage.Reserved[ip.String()] = desc
+	z.reservedIndex.add(ip.String(), desc.GetLabels())
+	z.reservedOrder.insert(ip.String())
+}
+
+// bucketPrefix return the common part of bucket keys as prefix/literal,
+// or prefix/namespace/literal if the zone is not in the default namespace
+func (z *zone) bucketPrefix(prefix string) string {
+	if z.storage.Namespace != DefaultNamespace {
+		prefix += "/" + z.storage.Namespace
+	}
+	return prefix + "/" + z.storage.Literal
+}
+
+// newBucketKey return an unused bucket key as bucketPrefix/index
+func (z *zone) newBucketKey(prefix string) string {
+	for index := len(z.storage.Buckets); ; index++ {
+		key := z.bucketPrefix(prefix) + "/" + strconv.Itoa(index)
+		if _, ok := z.storage.Buckets[key]; !ok {
+			return key
+		}
+	}
 }
 
 func (z *zone) AlocAddrWithCreateBucket(prefix string, ip net.IP, labels LabelMap) {
-	var bucket *Bucket
 	if z.storage.Buckets == nil {
 		z.storage.Buckets = make(map[string]*Bucket)
 	}
-	for _, b := range z.storage.Buckets {
-		// if found, increase RefCount and update Labels
-		if desc, ok := b.Used[ip.String()]; ok {
+	// if found, increase RefCount and update Labels
+	if _, ok := z.GetAddrDesc(ip); ok {
+		z.Update(ip, func(desc *Descriptor) {
 			desc.RefCount++
+			if desc.Labels == nil && len(labels) > 0 {
+				desc.Labels = make(map[string]string)
+			}
 			for k, v := range labels {
 				desc.Labels[k] = v
 			}
-			return
-		}
-		if len(b.Used) < AddrNumPerBucket {
-			bucket = b
-			break
+		})
+		return
+	}
+	var bucket *Bucket
+	bucketKey := ""
+	for key, b := range z.storage.Buckets {
+		// buckets not loaded yet and the history bucket are skipped, and the least key is chosen to keep
+		// the layout deterministic
+		if b != nil && !isHistoryBucket(key) && len(b.Used) < AddrNumPerBucket && (bucket == nil || key < bucketKey) {
+			bucket, bucketKey = b, key
 		}
 	}
 	if bucket == nil {
 		bucket = &Bucket{Used: make(map[string]*Descriptor)}
-		key := prefix + "/" + z.storage.Literal + "/" + strconv.Itoa(len(z.storage.Buckets))
-		z.storage.Buckets[key] = bucket
+		z.storage.Buckets[z.newBucketKey(prefix)] = bucket
 	}
-	desc := &Descriptor{RefCount: 1}
+	desc := &Descriptor{RefCount: 1, Revision: 1}
 	if labels != nil {
 		desc.Labels = labels.Copy()
 	}
 	bucket.Used[ip.String()] = desc
+	z.usedIndex.add(ip.String(), desc.Labels)
+	z.usedOrder.insert(ip.String())
 }
 
-func (z *zone) ReleaseAddrWithDeleteBucket(ip net.IP) {
+// ReleaseAddrWithDeleteBucket return the descriptor of ip if it is not used or reserved any more
+func (z *zone) ReleaseAddrWithDeleteBucket(ip net.IP) *Descriptor {
 	// query from Reserved at first
-	if _, reserved := z.storage.Reserved[ip.String()]; reserved {
+	if desc, reserved := z.storage.Reserved[ip.String()]; reserved {
 		delete(z.storage.Reserved, ip.String())
-		return
+		z.reservedIndex.remove(ip.String(), desc.GetLabels())
+		z.reservedOrder.remove(ip.String())
+		return desc
 	}
 	for key, bucket := range z.storage.Buckets {
-		desc, ok := bucket.Used[ip.String()]
+		desc, ok := bucket.GetUsed()[ip.String()]
 		if !ok {
 			continue
 		}
 		desc.RefCount--
 		if desc.RefCount > 0 {
-			return
+			return nil
 		}
 		delete(bucket.Used, ip.String())
+		z.usedIndex.remove(ip.String(), desc.GetLabels())
+		z.usedOrder.remove(ip.String())
 		if len(bucket.Used) <= 0 {
 			delete(z.storage.Buckets, key)
 		}
-		return
+		return desc
 	}
+	return nil
 }